@@ -0,0 +1,221 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chromedp/cdproto/accessibility"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/chromedp"
+)
+
+// AXNode is a node of the accessibility tree as exposed to assistive technologies.
+// Nodes ignored by the accessibility tree are omitted and their children are promoted.
+type AXNode struct {
+	Role          string            // Computed role, such as heading, link or StaticText
+	Name          string            // Accessible name
+	Value         string            // Value of controls such as text boxes and sliders
+	Description   string            // Accessible description
+	States        map[string]string // Other properties, such as checked, disabled or level, with "true" for set flags
+	Children      []*AXNode         // Child nodes
+	BackendNodeID cdp.BackendNodeID // Backend ID of the associated DOM node, zero if none
+}
+
+// axValue formats the value of an accessibility property.
+func axValue(v *accessibility.Value) string {
+	if v == nil || len(v.Value) == 0 {
+		return ""
+	}
+	var value any
+	if err := json.Unmarshal(v.Value, &value); err != nil {
+		return string(v.Value)
+	}
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// axTree converts the nodes returned by the Accessibility domain into AXNode trees.
+type axTree map[accessibility.NodeID]*accessibility.Node
+
+// convert returns the AXNode for id, or the converted children of id if it is ignored.
+// The root of a snapshot is kept even if ignored.
+func (t axTree) convert(id accessibility.NodeID, root bool) []*AXNode {
+	n, ok := t[id]
+	if !ok {
+		return nil
+	}
+	var children []*AXNode
+	for _, id := range n.ChildIDs {
+		children = append(children, t.convert(id, false)...)
+	}
+	role := axValue(n.Role)
+	if (n.Ignored || role == "InlineTextBox") && !root {
+		return children
+	}
+	node := &AXNode{
+		Role:          role,
+		Name:          axValue(n.Name),
+		Value:         axValue(n.Value),
+		Description:   axValue(n.Description),
+		Children:      children,
+		BackendNodeID: n.BackendDOMNodeID,
+	}
+	for _, p := range n.Properties {
+		if v := axValue(p.Value); v != "" && v != "false" {
+			if node.States == nil {
+				node.States = make(map[string]string)
+			}
+			node.States[string(p.Name)] = v
+		}
+	}
+	// Text that only repeats the name of its parent adds nothing.
+	if len(children) == 1 && children[0].Role == "StaticText" && children[0].Name == node.Name && len(children[0].Children) == 0 {
+		node.Children = nil
+	}
+	return []*AXNode{node}
+}
+
+// AXSnapshot returns the accessibility tree of the first element matching root, or of the whole
+// document if root is empty. The element must be in the main frame.
+func AXSnapshot(ctx context.Context, root string) (*AXNode, error) {
+	var backendID cdp.BackendNodeID
+	var nodes []*accessibility.Node
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		if root != "" {
+			var elements []*cdp.Node
+			if err := chromedp.Nodes(root, &elements, chromedp.ByQueryAll, chromedp.AtLeast(0)).Do(ctx); err != nil {
+				return err
+			}
+			if len(elements) == 0 {
+				return fmt.Errorf("%w: %s", ErrElementNotFound, root)
+			}
+			backendID = elements[0].BackendNodeID
+		}
+		var err error
+		nodes, err = accessibility.GetFullAXTree().Do(ctx)
+		return err
+	})); err != nil {
+		return nil, err
+	}
+	tree := make(axTree, len(nodes))
+	for _, n := range nodes {
+		tree[n.NodeID] = n
+	}
+	for _, n := range nodes {
+		if (root == "" && n.ParentID == "") || (root != "" && n.BackendDOMNodeID == backendID) {
+			return tree.convert(n.NodeID, true)[0], nil
+		}
+	}
+	if root == "" {
+		return nil, fmt.Errorf("accessibility tree has no root")
+	}
+	// Elements missing from the full tree, for example in display:none subtrees, have a partial tree only.
+	var partial []*accessibility.Node
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) (err error) {
+		partial, err = accessibility.GetPartialAXTree().WithBackendNodeID(backendID).WithFetchRelatives(false).Do(ctx)
+		return
+	})); err != nil {
+		return nil, err
+	}
+	if len(partial) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrElementNotFound, root)
+	}
+	return axTree{partial[0].NodeID: partial[0]}.convert(partial[0].NodeID, true)[0], nil
+}
+
+// AXSnapshot returns the accessibility tree of an element of the page of this Chrome instance.
+func (c *Chrome) AXSnapshot(root string) (*AXNode, error) {
+	return AXSnapshot(c, root)
+}
+
+// Walk calls fn for n and its descendants in depth-first order until fn returns false.
+func (n *AXNode) Walk(fn func(*AXNode) bool) bool {
+	if !fn(n) {
+		return false
+	}
+	for _, c := range n.Children {
+		if !c.Walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find returns the nodes of the tree for which fn returns true, in depth-first order.
+func (n *AXNode) Find(fn func(*AXNode) bool) (nodes []*AXNode) {
+	n.Walk(func(n *AXNode) bool {
+		if fn(n) {
+			nodes = append(nodes, n)
+		}
+		return true
+	})
+	return
+}
+
+// FindByRole returns the nodes with the role and, unless name is empty, the accessible name.
+func (n *AXNode) FindByRole(role, name string) []*AXNode {
+	return n.Find(func(n *AXNode) bool {
+		return strings.EqualFold(n.Role, role) && (name == "" || n.Name == name)
+	})
+}
+
+// write writes the node and its descendants in the snapshot format.
+func (n *AXNode) write(b *strings.Builder, depth int) {
+	b.WriteString(strings.Repeat("  ", depth) + "- " + n.Role)
+	if n.Name != "" {
+		b.WriteString(" " + strconv.Quote(n.Name))
+	}
+	var states []string
+	for k, v := range n.States {
+		if v != "true" {
+			k += "=" + strconv.Quote(v)
+		}
+		states = append(states, k)
+	}
+	if n.Value != "" && len(n.Children) > 0 {
+		states = append(states, "value="+strconv.Quote(n.Value))
+	}
+	if len(states) > 0 {
+		slices.Sort(states)
+		b.WriteString(" [" + strings.Join(states, " ") + "]")
+	}
+	switch {
+	case len(n.Children) > 0:
+		b.WriteString(":\n")
+		for _, c := range n.Children {
+			c.write(b, depth+1)
+		}
+	case n.Value != "":
+		b.WriteString(": " + strconv.Quote(n.Value) + "\n")
+	default:
+		b.WriteString("\n")
+	}
+}
+
+// String returns the tree in a stable YAML-like text form suitable for golden files, one node
+// per line as `- role "name" [state state="value"]`, followed by `: "value"` for leaf values or
+// by indented children, with states sorted by name. Descriptions and backend node IDs are not included.
+func (n *AXNode) String() string {
+	var b strings.Builder
+	n.write(&b, 0)
+	return b.String()
+}
+
+// WriteTo writes the text form of the tree to w.
+func (n *AXNode) WriteTo(w io.Writer) (int64, error) {
+	c, err := io.WriteString(w, n.String())
+	return int64(c), err
+}
@@ -0,0 +1,93 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/accessibility"
+	"github.com/chromedp/chromedp"
+)
+
+func axTestValue(v string) *accessibility.Value {
+	return &accessibility.Value{Type: accessibility.ValueTypeString, Value: []byte(`"` + v + `"`)}
+}
+
+func TestAXTree(t *testing.T) {
+	tree := axTree{
+		"1": {NodeID: "1", Role: axTestValue("RootWebArea"), Name: axTestValue("Page"), ChildIDs: []accessibility.NodeID{"2"}},
+		"2": {NodeID: "2", Ignored: true, ChildIDs: []accessibility.NodeID{"3", "5", "6"}},
+		"3": {NodeID: "3", Role: axTestValue("heading"), Name: axTestValue("Title"), ChildIDs: []accessibility.NodeID{"4"},
+			Properties: []*accessibility.Property{{Name: "level", Value: &accessibility.Value{Type: accessibility.ValueTypeInteger, Value: []byte("1")}}}},
+		"4": {NodeID: "4", Role: axTestValue("StaticText"), Name: axTestValue("Title")},
+		"5": {NodeID: "5", Role: axTestValue("textbox"), Name: axTestValue("Name"), Value: axTestValue("Ann"),
+			Properties: []*accessibility.Property{
+				{Name: "required", Value: &accessibility.Value{Type: accessibility.ValueTypeBoolean, Value: []byte("true")}},
+				{Name: "disabled", Value: &accessibility.Value{Type: accessibility.ValueTypeBoolean, Value: []byte("false")}},
+			}},
+		"6": {NodeID: "6", Role: axTestValue("button"), Name: axTestValue("Send")},
+	}
+	root := tree.convert("1", true)[0]
+	expect := `- RootWebArea "Page":
+  - heading "Title" [level="1"]
+  - textbox "Name" [required]: "Ann"
+  - button "Send"
+`
+	if s := root.String(); s != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, s)
+	}
+	if nodes := root.FindByRole("button", "Send"); len(nodes) != 1 || nodes[0].Name != "Send" {
+		t.Errorf("expected button Send; got %v", nodes)
+	}
+	if nodes := root.FindByRole("heading", ""); len(nodes) != 1 || nodes[0].States["level"] != "1" {
+		t.Errorf("expected heading with level 1; got %v", nodes)
+	}
+}
+
+func TestAXSnapshot(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<!DOCTYPE html><html><head><title>Test</title></head><body>
+<h1>Title</h1>
+<form id="form"><label>Name <input type="text" value="Ann" required></label><button>Send</button></form>
+</body></html>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	root, err := AXSnapshot(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nodes := root.FindByRole("heading", "Title"); len(nodes) != 1 || nodes[0].States["level"] != "1" {
+		t.Errorf("expected heading Title; got\n%s", root)
+	}
+
+	form, err := AXSnapshot(ctx, "#form")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(form.FindByRole("heading", "")) != 0 {
+		t.Errorf("expected snapshot limited to form; got\n%s", form)
+	}
+	if nodes := form.FindByRole("textbox", "Name"); len(nodes) != 1 || nodes[0].Value != "Ann" {
+		t.Errorf("expected textbox Name; got\n%s", form)
+	}
+	if s := form.String(); !strings.Contains(s, `button "Send"`) {
+		t.Errorf("expected button Send; got\n%s", s)
+	}
+	if _, err := AXSnapshot(ctx, "#missing"); err == nil {
+		t.Error("expected error; got nil")
+	}
+}
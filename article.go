@@ -0,0 +1,182 @@
+package chrome
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// extractArticleScript finds the main content of the document with a readability-style scoring pass
+// and collects the article metadata from meta tags, JSON-LD and common markup.
+const extractArticleScript = `(() => {` + serializeScript + `
+	const meta = (...names) => {
+		for (const n of names) {
+			const el = document.querySelector('meta[property="' + n + '"], meta[name="' + n + '"], meta[itemprop="' + n + '"]');
+			if (el && el.content && el.content.trim()) return el.content.trim();
+		}
+		return "";
+	};
+	let ld = {};
+	for (const s of document.querySelectorAll('script[type="application/ld+json"]')) {
+		try {
+			let data = JSON.parse(s.textContent);
+			if (data && data["@graph"]) data = data["@graph"];
+			const found = [].concat(data).find(d => d && /Article|Posting|Report/.test([].concat(d["@type"]).join(" ")));
+			if (found) {
+				ld = found;
+				break;
+			}
+		} catch {}
+	}
+	const names = v => typeof v === "string" ? v :
+		[].concat(v || []).map(a => a && (a.name || a)).filter(a => typeof a === "string").join(", ");
+
+	const positive = /article|body|content|entry|hentry|main|page|post|text|blog|story/i;
+	const negative = /comment|meta|footer|footnote|sidebar|sponsor|ad-break|agegate|pagination|popup|share|social|related|promo|nav|menu|widget|masthead|banner|combx/i;
+	const classWeight = el => {
+		let w = 0;
+		for (const s of [el.className, el.id]) {
+			if (typeof s !== "string" || !s) continue;
+			if (negative.test(s)) w -= 25;
+			if (positive.test(s)) w += 25;
+		}
+		return w;
+	};
+	const tagWeight = {
+		ARTICLE: 10, MAIN: 10, DIV: 5, SECTION: 3, PRE: 3, TD: 3, BLOCKQUOTE: 3,
+		ADDRESS: -3, OL: -3, UL: -3, DL: -3, DD: -3, DT: -3, LI: -3, FORM: -3,
+		H1: -5, H2: -5, H3: -5, H4: -5, H5: -5, H6: -5, TH: -5,
+	};
+	const linkDensity = el => {
+		const length = el.textContent.length || 1;
+		let links = 0;
+		for (const a of el.querySelectorAll("a")) links += a.textContent.length;
+		return links / length;
+	};
+
+	const scores = new Map();
+	const add = (el, score) => {
+		if (!el || el === document.documentElement) return;
+		if (!scores.has(el)) scores.set(el, (tagWeight[el.tagName] || 0) + classWeight(el));
+		scores.set(el, scores.get(el) + score);
+	};
+	for (const el of document.body.querySelectorAll("p, pre, td, blockquote")) {
+		if (el.closest("nav, aside, footer, form")) continue;
+		const text = el.textContent.trim();
+		if (text.length < 25) continue;
+		const score = 1 + (text.match(/[,，]/g) || []).length + Math.min(Math.floor(text.length / 100), 3);
+		add(el.parentElement, score);
+		add(el.parentElement && el.parentElement.parentElement, score / 2);
+	}
+	let top = null, best = 0;
+	for (const [el, score] of scores) {
+		const s = score * (1 - linkDensity(el));
+		scores.set(el, s);
+		if (!top || s > best) [top, best] = [el, s];
+	}
+	if (!top) top = document.querySelector("article, main") || document.body;
+
+	const content = document.createElement("div");
+	const threshold = Math.max(10, best * 0.2);
+	const siblings = top.parentElement && top !== document.body ? Array.from(top.parentElement.children) : [top];
+	for (const el of siblings) {
+		let keep = el === top || (scores.get(el) || 0) >= threshold;
+		if (!keep && el.tagName === "P") keep = el.textContent.trim().length > 80 && linkDensity(el) < 0.25;
+		if (keep) content.append(el.cloneNode(true));
+	}
+	for (const el of content.querySelectorAll("script, style, noscript, iframe, form, nav, aside, footer, button, input, select, textarea, svg, object, embed")) {
+		el.remove();
+	}
+	for (const el of content.querySelectorAll("div, section, ul, ol, table")) {
+		if (content.contains(el) && classWeight(el) < 0 && !el.querySelector("pre, table, img")) el.remove();
+	}
+	for (const img of content.querySelectorAll("img")) {
+		const src = img.getAttribute("data-src") || img.getAttribute("data-original");
+		const current = img.getAttribute("src");
+		if (src && (!current || current.startsWith("data:"))) img.setAttribute("src", src);
+	}
+
+	const h1 = document.querySelector("h1");
+	const author = document.querySelector('[rel="author"], [itemprop="author"], .byline, .author');
+	const time = document.querySelector("time[datetime]");
+	const ldImage = [].concat(ld.image || [])[0];
+	const leadImage = content.querySelector("img");
+	return {
+		title: meta("og:title", "twitter:title") || ld.headline || (h1 && h1.textContent.trim()) || document.title,
+		byline: meta("author", "article:author", "byl") || names(ld.author) || (author && author.textContent.trim()) || "",
+		published: meta("article:published_time", "datePublished", "pubdate", "date") || ld.datePublished || (time && time.dateTime) || "",
+		image: abs(meta("og:image", "twitter:image") || (typeof ldImage === "string" ? ldImage : ldImage && ldImage.url) ||
+			(leadImage && leadImage.getAttribute("src"))),
+		content: serialize(content),
+	};
+})()`
+
+// Article is the main content of a page with its metadata.
+type Article struct {
+	Title     string    // Title of the article
+	Byline    string    // Author of the article
+	Published time.Time // Publication time, zero if unknown
+	Image     string    // Absolute URL of the lead image
+	Text      string    // Plain text of the main content
+	Content   *HTMLNode // Main content
+}
+
+// Markdown returns the main content of the article as Markdown.
+func (a *Article) Markdown() string {
+	return Markdown(a.Content)
+}
+
+// publishedLayouts are the layouts tried when parsing publication times.
+var publishedLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// parsePublished parses a publication time, returning the zero time if the format is unknown.
+func parsePublished(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range publishedLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ExtractArticle extracts the main content of the rendered page. The content element is chosen by
+// scoring paragraphs and their ancestors by text length, commas, link density and class names, and
+// related siblings are kept. Scripts, navigation, forms and low-scoring boilerplate are removed, and
+// links and images are resolved to absolute URLs. Metadata comes from meta tags, JSON-LD and markup.
+func ExtractArticle(ctx context.Context) (*Article, error) {
+	var res struct {
+		Title     string    `json:"title"`
+		Byline    string    `json:"byline"`
+		Published string    `json:"published"`
+		Image     string    `json:"image"`
+		Content   *HTMLNode `json:"content"`
+	}
+	if err := chromedp.Run(ctx, chromedp.Evaluate(extractArticleScript, &res)); err != nil {
+		return nil, err
+	}
+	return &Article{
+		Title:     res.Title,
+		Byline:    res.Byline,
+		Published: parsePublished(res.Published),
+		Image:     res.Image,
+		Text:      PlainText(res.Content),
+		Content:   res.Content,
+	}, nil
+}
+
+// ExtractArticle extracts the main content of the page of this Chrome instance.
+func (c *Chrome) ExtractArticle() (*Article, error) {
+	return ExtractArticle(c)
+}
@@ -0,0 +1,76 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestExtractArticle(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<!DOCTYPE html><html><head>
+<title>Site | Story</title>
+<meta property="og:title" content="Story">
+<meta name="author" content="Jane Doe">
+<meta property="article:published_time" content="2024-05-01T08:00:00Z">
+</head><body>
+<nav class="menu"><a href="/">Home</a><a href="/news">News</a></nav>
+<div class="content"><article>
+<h2>Heading</h2>
+<p>The first paragraph of the story, with commas, details, and enough text to be scored as content.</p>
+<p><img src="/lead.png" alt="lead"> The second paragraph continues the story, adding more words, more commas, and more length.</p>
+<pre><code class="language-go">fmt.Println("hello")</code></pre>
+<p>Read <a href="/more">more</a> about it in the third paragraph of the story, which is also long enough.</p>
+</article></div>
+<div class="sidebar related"><p>Related links, sponsored stories, and other things, which should be removed.</p></div>
+<footer><p>Copyright notice of the site, with some words, which should be ignored.</p></footer>
+</body></html>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	a, err := ExtractArticle(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Title != "Story" {
+		t.Errorf("expected title %q; got %q", "Story", a.Title)
+	}
+	if a.Byline != "Jane Doe" {
+		t.Errorf("expected byline %q; got %q", "Jane Doe", a.Byline)
+	}
+	if expect := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC); !a.Published.Equal(expect) {
+		t.Errorf("expected published %s; got %s", expect, a.Published)
+	}
+	if expect := ts.URL + "/lead.png"; a.Image != expect {
+		t.Errorf("expected image %q; got %q", expect, a.Image)
+	}
+	if !strings.Contains(a.Text, "first paragraph") || strings.Contains(a.Text, "Related links") || strings.Contains(a.Text, "Copyright") {
+		t.Errorf("unexpected text: %s", a.Text)
+	}
+	md := a.Markdown()
+	for _, s := range []string{
+		"## Heading",
+		"![lead](" + ts.URL + "/lead.png)",
+		"[more](" + ts.URL + "/more)",
+		"```go\nfmt.Println(\"hello\")\n```",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("expected markdown to contain %q; got\n%s", s, md)
+		}
+	}
+}
@@ -21,13 +21,19 @@ import (
 // Chrome represents a Chrome/Chromium browser instance with configuration options.
 // It provides a fluent API for setting up and managing browser sessions.
 type Chrome struct {
-	url              string      // Target URL for the browser instance
-	useragent        string      // Custom user agent string
-	width            int         // Browser window width
-	height           int         // Browser window height
-	proxy            string      // Proxy URL for network requests
-	enableExtensions bool        // Whether to enable Chrome extensions
-	debugger         *log.Logger // Logger for debug output
+	url              string           // Target URL for the browser instance
+	useragent        string           // Custom user agent string
+	width            int              // Browser window width
+	height           int              // Browser window height
+	proxy            string           // Proxy URL for network requests
+	enableExtensions bool             // Whether to enable Chrome extensions
+	execPath         string           // Browser executable path
+	version          string           // Pinned browser version in BrowsersDir
+	env              []string         // Extra environment variables for the browser process
+	output           io.Writer        // Destination for browser process stdout and stderr
+	executable       string           // Executable used by the running browser
+	debugger         *log.Logger      // Logger for debug output
+	guard            *NavigationGuard // Navigation guard applied to all tabs
 
 	flags   []chromedp.ExecAllocatorOption // Chrome execution flags
 	ctxOpts []chromedp.ContextOption       // Context options for chromedp
@@ -162,6 +168,39 @@ func (c *Chrome) EnableExtensions(enable bool) *Chrome {
 	return c
 }
 
+// ExecPath sets the browser executable path.
+// If not set, the executable is located by FindExecPath.
+func (c *Chrome) ExecPath(path string) *Chrome {
+	c.execPath = path
+	return c
+}
+
+// BrowserVersion pins the browser build in BrowsersDir used when no executable path is set.
+func (c *Chrome) BrowserVersion(version string) *Chrome {
+	c.version = version
+	return c
+}
+
+// Env appends environment variables in the form KEY=VALUE for the browser process.
+func (c *Chrome) Env(kv ...string) *Chrome {
+	c.env = append(c.env, kv...)
+	return c
+}
+
+// ChromeOutput sets the writer that receives the browser process's stdout and stderr.
+func (c *Chrome) ChromeOutput(w io.Writer) *Chrome {
+	c.output = w
+	return c
+}
+
+// Executable returns the path of the browser executable chosen for this instance.
+// It is empty until the browser has been started or when connected to a remote browser.
+func (c *Chrome) Executable() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.executable
+}
+
 // AddFlags appends Chrome execution allocator options/flags.
 func (c *Chrome) AddFlags(flags ...chromedp.ExecAllocatorOption) *Chrome {
 	c.flags = append(c.flags, flags...)
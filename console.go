@@ -0,0 +1,253 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// ConsoleMessage represents a call to a console API method in the page.
+type ConsoleMessage struct {
+	Level      string                  // Console method, e.g. log, info, warning, error, debug
+	Text       string                  // Arguments formatted as text and joined by spaces
+	Args       []any                   // Arguments converted to Go values
+	RawArgs    []*runtime.RemoteObject // Arguments as reported by the protocol
+	URL        string                  // Source URL of the call
+	Line       int64                   // 1-based source line of the call
+	Column     int64                   // 1-based source column of the call
+	StackTrace *runtime.StackTrace     // Stack trace captured when the call was made
+	Timestamp  time.Time               // Time of the call
+}
+
+// String returns the message in the form "level: text".
+func (m *ConsoleMessage) String() string {
+	return m.Level + ": " + m.Text
+}
+
+// JSException is an uncaught JavaScript exception thrown in the page.
+type JSException struct {
+	Text       string                    // Exception text, usually "Uncaught"
+	Message    string                    // First line of the exception description, e.g. "Error: boom"
+	URL        string                    // Source URL of the exception
+	Line       int64                     // 1-based source line of the exception
+	Column     int64                     // 1-based source column of the exception
+	StackTrace *runtime.StackTrace       // JavaScript stack trace if available
+	Timestamp  time.Time                 // Time of the exception
+	Details    *runtime.ExceptionDetails // Exception details as reported by the protocol
+}
+
+// Error implements the error interface.
+func (e *JSException) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Text)
+	if e.Message != "" {
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(e.Message)
+	}
+	if e.URL != "" {
+		fmt.Fprintf(&b, " (%s:%d:%d)", e.URL, e.Line, e.Column)
+	}
+	return b.String()
+}
+
+// remoteValue converts a remote object to a Go value.
+// Primitives and JSON values are decoded, special numbers become float64 or *big.Int,
+// undefined becomes nil and other objects are represented by their description.
+func remoteValue(o *runtime.RemoteObject) any {
+	if o == nil || o.Type == runtime.TypeUndefined {
+		return nil
+	}
+	if len(o.Value) > 0 {
+		var v any
+		if err := json.Unmarshal(o.Value, &v); err == nil {
+			return v
+		}
+	}
+	switch s := string(o.UnserializableValue); s {
+	case "":
+	case "NaN":
+		return math.NaN()
+	case "Infinity":
+		return math.Inf(1)
+	case "-Infinity":
+		return math.Inf(-1)
+	case "-0":
+		return math.Copysign(0, -1)
+	default:
+		if n, ok := new(big.Int).SetString(strings.TrimSuffix(s, "n"), 10); ok {
+			return n
+		}
+		return s
+	}
+	if o.Subtype == runtime.SubtypeNull {
+		return nil
+	}
+	return o.Description
+}
+
+// remoteText formats a remote object the way the console prints it.
+func remoteText(o *runtime.RemoteObject) string {
+	switch {
+	case o == nil:
+		return ""
+	case o.Type == runtime.TypeUndefined:
+		return "undefined"
+	case o.Type == runtime.TypeString:
+		if s, ok := remoteValue(o).(string); ok {
+			return s
+		}
+	case o.UnserializableValue != "":
+		return string(o.UnserializableValue)
+	case o.Description != "":
+		return o.Description
+	}
+	return string(o.Value)
+}
+
+// stackLocation returns the 1-based location of the top frame of a stack trace.
+func stackLocation(st *runtime.StackTrace) (url string, line, column int64) {
+	if st == nil || len(st.CallFrames) == 0 {
+		return
+	}
+	f := st.CallFrames[0]
+	return f.URL, f.LineNumber + 1, f.ColumnNumber + 1
+}
+
+// newConsoleMessage converts a console API event.
+func newConsoleMessage(ev *runtime.EventConsoleAPICalled) *ConsoleMessage {
+	m := &ConsoleMessage{Level: string(ev.Type), RawArgs: ev.Args, StackTrace: ev.StackTrace}
+	text := make([]string, len(ev.Args))
+	for i, arg := range ev.Args {
+		m.Args = append(m.Args, remoteValue(arg))
+		text[i] = remoteText(arg)
+	}
+	m.Text = strings.Join(text, " ")
+	m.URL, m.Line, m.Column = stackLocation(ev.StackTrace)
+	if ev.Timestamp != nil {
+		m.Timestamp = ev.Timestamp.Time()
+	}
+	return m
+}
+
+// newJSException converts an exception event.
+func newJSException(ev *runtime.EventExceptionThrown) *JSException {
+	e := &JSException{Timestamp: time.Now()}
+	if ev.Timestamp != nil {
+		e.Timestamp = ev.Timestamp.Time()
+	}
+	d := ev.ExceptionDetails
+	if d == nil {
+		return e
+	}
+	e.Details = d
+	e.Text = d.Text
+	e.StackTrace = d.StackTrace
+	if d.Exception != nil {
+		e.Message, _, _ = strings.Cut(remoteText(d.Exception), "\n")
+	}
+	if e.URL, e.Line, e.Column = stackLocation(d.StackTrace); e.URL == "" {
+		e.URL, e.Line, e.Column = d.URL, d.LineNumber+1, d.ColumnNumber+1
+	}
+	return e
+}
+
+// listenTarget delivers values converted from target events to the returned channel in order
+// without blocking the event listener. The channel is closed when ctx is done.
+func listenTarget[T any](ctx context.Context, fn func(v any) (T, bool)) <-chan T {
+	c := make(chan T, DefaultChannelBufferCapacity)
+	notify := make(chan struct{}, 1)
+	var mu sync.Mutex
+	var queue []T
+	chromedp.ListenTarget(ctx, func(v any) {
+		if t, ok := fn(v); ok {
+			mu.Lock()
+			queue = append(queue, t)
+			mu.Unlock()
+			select {
+			case notify <- struct{}{}:
+			default:
+			}
+		}
+	})
+	go func() {
+		defer close(c)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-notify:
+			}
+			mu.Lock()
+			values := queue
+			queue = nil
+			mu.Unlock()
+			for _, v := range values {
+				select {
+				case c <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return c
+}
+
+// ListenConsole listens for console API calls in the page.
+// Messages are delivered in order until ctx is done.
+func ListenConsole(ctx context.Context) <-chan *ConsoleMessage {
+	return listenTarget(ctx, func(v any) (*ConsoleMessage, bool) {
+		if ev, ok := v.(*runtime.EventConsoleAPICalled); ok {
+			return newConsoleMessage(ev), true
+		}
+		return nil, false
+	})
+}
+
+// ListenExceptions listens for uncaught exceptions thrown in the page.
+// Each exception is delivered as a *JSException until ctx is done.
+func ListenExceptions(ctx context.Context) <-chan error {
+	return listenTarget(ctx, func(v any) (error, bool) {
+		if ev, ok := v.(*runtime.EventExceptionThrown); ok {
+			return newJSException(ev), true
+		}
+		return nil, false
+	})
+}
+
+// FailOnException returns a copy of ctx that is canceled when the page throws an uncaught exception,
+// so that actions run with it fail. context.Cause on the returned context reports the *JSException.
+func FailOnException(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	chromedp.ListenTarget(ctx, func(v any) {
+		if ev, ok := v.(*runtime.EventExceptionThrown); ok {
+			cancel(newJSException(ev))
+		}
+	})
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+// ListenConsole listens for console API calls in the page of this Chrome instance.
+func (c *Chrome) ListenConsole() <-chan *ConsoleMessage {
+	return ListenConsole(c)
+}
+
+// ListenExceptions listens for uncaught exceptions thrown in the page of this Chrome instance.
+func (c *Chrome) ListenExceptions() <-chan error {
+	return ListenExceptions(c)
+}
+
+// FailOnException returns a context of this Chrome instance that is canceled by an uncaught exception.
+func (c *Chrome) FailOnException() (context.Context, context.CancelFunc) {
+	return FailOnException(c)
+}
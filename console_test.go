@@ -0,0 +1,102 @@
+package chrome
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+func TestRemoteValue(t *testing.T) {
+	for _, tc := range []struct {
+		object *runtime.RemoteObject
+		value  any
+		text   string
+	}{
+		{&runtime.RemoteObject{Type: runtime.TypeString, Value: []byte(`"a"`)}, "a", "a"},
+		{&runtime.RemoteObject{Type: runtime.TypeNumber, Value: []byte(`1.5`), Description: "1.5"}, 1.5, "1.5"},
+		{&runtime.RemoteObject{Type: runtime.TypeBoolean, Value: []byte(`true`)}, true, "true"},
+		{&runtime.RemoteObject{Type: runtime.TypeUndefined}, nil, "undefined"},
+		{&runtime.RemoteObject{Type: runtime.TypeObject, Subtype: runtime.SubtypeNull, Value: []byte(`null`)}, nil, "null"},
+		{&runtime.RemoteObject{Type: runtime.TypeNumber, UnserializableValue: "Infinity", Description: "Infinity"}, math.Inf(1), "Infinity"},
+		{&runtime.RemoteObject{Type: runtime.TypeObject, ClassName: "Object", Description: "Object"}, "Object", "Object"},
+	} {
+		if v := remoteValue(tc.object); v != tc.value {
+			t.Errorf("expected %v; got %v", tc.value, v)
+		}
+		if s := remoteText(tc.object); s != tc.text {
+			t.Errorf("expected %q; got %q", tc.text, s)
+		}
+	}
+	if v := remoteValue(&runtime.RemoteObject{Type: runtime.TypeBigint, UnserializableValue: "12345678901234567890n"}); v == nil || v.(interface{ String() string }).String() != "12345678901234567890" {
+		t.Errorf("unexpected bigint value: %v", v)
+	}
+}
+
+func TestConsole(t *testing.T) {
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	console, exceptions := ListenConsole(ctx), ListenExceptions(ctx)
+	if err := chromedp.Run(ctx, chromedp.Evaluate(`console.log("a", 1, true); setTimeout(() => { throw new Error("boom") })`, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	case m := <-console:
+		if m.Level != "log" {
+			t.Errorf("expected level log; got %q", m.Level)
+		}
+		if expect := "a 1 true"; m.Text != expect {
+			t.Errorf("expected %q; got %q", expect, m.Text)
+		}
+		if len(m.Args) != 3 || m.Args[0] != "a" || m.Args[1] != 1.0 || m.Args[2] != true {
+			t.Errorf("unexpected args: %v", m.Args)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	case err := <-exceptions:
+		var e *JSException
+		if !errors.As(err, &e) {
+			t.Fatalf("expected *JSException; got %T", err)
+		}
+		if expect := "Error: boom"; e.Message != expect {
+			t.Errorf("expected %q; got %q", expect, e.Message)
+		}
+	}
+}
+
+func TestFailOnException(t *testing.T) {
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	ctx, cancel = FailOnException(ctx)
+	defer cancel()
+
+	err := chromedp.Run(ctx,
+		chromedp.Evaluate(`setTimeout(() => { throw new Error("boom") })`, nil),
+		chromedp.Sleep(5*time.Second),
+	)
+	if err == nil {
+		t.Fatal("expected error; got nil")
+	}
+	var e *JSException
+	if !errors.As(context.Cause(ctx), &e) {
+		t.Fatalf("expected *JSException cause; got %v", context.Cause(ctx))
+	}
+}
@@ -44,7 +44,28 @@ func (c *Chrome) context(ctx context.Context, reset bool) (context.Context, cont
 		ctx, cancelCause := context.WithCancelCause(ctx)
 		var allocatorCancel context.CancelFunc
 		if c.url == "" {
-			opts := DefaultExecAllocatorOptions[:]
+			execPath := c.execPath
+			if execPath == "" {
+				var err error
+				if execPath, err = FindExecPath(c.version); err != nil {
+					if c.version != "" {
+						cancelCause(err)
+						return ctx, nil, false, err
+					}
+					// Nothing found: launch the first common name so that the
+					// launch error and Executable report what was tried.
+					execPath = installLocations()[0]
+				}
+			}
+			c.debugger.Printf("chrome executable: %s", execPath)
+			opts := append(DefaultExecAllocatorOptions[:], chromedp.ExecPath(execPath))
+			c.executable = execPath
+			if len(c.env) > 0 {
+				opts = append(opts, chromedp.Env(c.env...))
+			}
+			if c.output != nil {
+				opts = append(opts, chromedp.CombinedOutput(c.output))
+			}
 			if c.useragent != "" {
 				opts = append(opts, chromedp.UserAgent(c.useragent))
 			}
@@ -0,0 +1,274 @@
+package chrome
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// NormalizeURL returns the canonical form of an absolute http or https URL used for deduplication.
+// The scheme and host are lowercased, default ports and the fragment are removed,
+// an empty path becomes "/" and query parameters are sorted.
+func NormalizeURL(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.New("unsupported url scheme: " + s)
+	}
+	if u.Host == "" {
+		return "", errors.New("missing url host: " + s)
+	}
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	u.Host = host
+	u.Fragment, u.RawFragment = "", ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawQuery = u.Query().Encode()
+	return u.String(), nil
+}
+
+// CrawlResult is the outcome of crawling a single page.
+type CrawlResult struct {
+	URL   string   // Normalized URL of the page
+	Depth int      // Number of links followed from a seed
+	Links []string // Normalized in-scope links found on the rendered page
+	Err   error    // Error navigating, handling or extracting links from the page
+}
+
+// CrawlerOptions configures a Crawler.
+// Zero values use the defaults noted on each field.
+type CrawlerOptions struct {
+	MaxDepth    int           // Maximum number of links followed from a seed, zero for the seeds only, negative for no limit
+	MaxPages    int           // Maximum number of pages crawled, zero for no limit
+	Scope       []any         // URL matchers of pages to crawl, the hosts of the seeds if empty
+	Exclude     []any         // URL matchers of pages never to crawl, seeds included
+	Concurrency int           // Number of tabs crawling concurrently, default 4
+	Delay       time.Duration // Minimum delay between requests to the same host, unused if the Chrome instance has a Guard
+	WaitUntil   WaitUntil     // Lifecycle event to wait for before a page is handled
+
+	// Handler is called with the rendered page before its links are extracted.
+	// A returned error is reported in the page's result.
+	Handler func(ctx context.Context, r *CrawlResult) error
+}
+
+// Crawler crawls web sites with concurrent tabs of a Chrome instance.
+type Crawler struct {
+	chrome *Chrome
+	opts   CrawlerOptions
+
+	mu    sync.Mutex
+	hosts map[string]time.Time // earliest time of the next request per host
+}
+
+// NewCrawler returns a Crawler using tabs of this Chrome instance.
+// A nil opts uses the default options.
+func (c *Chrome) NewCrawler(opts *CrawlerOptions) *Crawler {
+	cr := &Crawler{chrome: c, hosts: make(map[string]time.Time)}
+	if opts != nil {
+		cr.opts = *opts
+	}
+	if cr.opts.Concurrency <= 0 {
+		cr.opts.Concurrency = 4
+	}
+	return cr
+}
+
+// excluded reports whether a normalized URL matches Exclude.
+func (cr *Crawler) excluded(u string) bool {
+	return slices.ContainsFunc(cr.opts.Exclude, func(m any) bool { return match(u, m) })
+}
+
+// inScope reports whether a normalized URL should be crawled.
+func (cr *Crawler) inScope(u string, hosts []string) bool {
+	if cr.excluded(u) {
+		return false
+	}
+	if len(cr.opts.Scope) == 0 {
+		parsed, err := url.Parse(u)
+		return err == nil && slices.Contains(hosts, parsed.Host)
+	}
+	for _, m := range cr.opts.Scope {
+		if match(u, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// wait blocks until a request to the host of u respects the politeness delay.
+func (cr *Crawler) wait(ctx context.Context, u string) error {
+	if cr.opts.Delay <= 0 {
+		return nil
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+	cr.mu.Lock()
+	now := time.Now()
+	next := now
+	if t := cr.hosts[parsed.Host]; t.After(now) {
+		next = t
+	}
+	cr.hosts[parsed.Host] = next.Add(cr.opts.Delay)
+	cr.mu.Unlock()
+	return sleep(ctx, next.Sub(now))
+}
+
+// crawl navigates p to the page of r, runs the handler and extracts the page's links.
+func (cr *Crawler) crawl(ctx context.Context, p *Page, r *CrawlResult) {
+	// The guard of the Chrome instance, if any, enforces robots.txt and rate limits on the tab.
+	if cr.chrome.guard == nil {
+		if r.Err = cr.wait(ctx, r.URL); r.Err != nil {
+			return
+		}
+	}
+	if r.Err = p.Goto(r.URL, cr.opts.WaitUntil); r.Err != nil {
+		return
+	}
+	if cr.opts.Handler != nil {
+		if r.Err = cr.opts.Handler(p, r); r.Err != nil {
+			return
+		}
+	}
+	var links []string
+	if r.Err = p.Run(chromedp.Evaluate(`Array.from(document.links, a => a.href)`, &links)); r.Err != nil {
+		return
+	}
+	for _, link := range links {
+		if u, err := NormalizeURL(link); err == nil && !slices.Contains(r.Links, u) {
+			r.Links = append(r.Links, u)
+		}
+	}
+}
+
+// Run crawls from the seed URLs, following in-scope links breadth first, and streams a result
+// for every page. URLs are normalized, each is crawled at most once and excluded seeds are skipped.
+// The channel is closed when there is nothing left to crawl, MaxPages is reached or ctx is done.
+func (cr *Crawler) Run(ctx context.Context, seeds ...string) <-chan *CrawlResult {
+	type task struct {
+		url   string
+		depth int
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	out := make(chan *CrawlResult, DefaultChannelBufferCapacity)
+	tasks, results := make(chan task), make(chan *CrawlResult)
+
+	var wg sync.WaitGroup
+	for range cr.opts.Concurrency {
+		wg.Go(func() {
+			var p *Page
+			var err error
+			for t := range tasks {
+				r := &CrawlResult{URL: t.url, Depth: t.depth}
+				if p == nil && err == nil {
+					if p, err = cr.chrome.NewPage(ctx); err == nil {
+						defer p.Close()
+					}
+				}
+				if r.Err = err; err == nil {
+					cr.crawl(ctx, p, r)
+				}
+				select {
+				case results <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}
+
+	go func() {
+		defer close(out)
+		defer wg.Wait()
+		defer cancel()
+		defer close(tasks)
+
+		send := func(r *CrawlResult) bool {
+			select {
+			case out <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		seen := make(map[string]bool)
+		var hosts []string
+		var queue []task
+		for _, seed := range seeds {
+			u, err := NormalizeURL(seed)
+			if err != nil {
+				if !send(&CrawlResult{URL: seed, Err: err}) {
+					return
+				}
+				continue
+			}
+			if parsed, err := url.Parse(u); err == nil && !slices.Contains(hosts, parsed.Host) {
+				hosts = append(hosts, parsed.Host)
+			}
+			if cr.excluded(u) {
+				continue
+			}
+			if !seen[u] {
+				seen[u] = true
+				queue = append(queue, task{u, 0})
+			}
+		}
+
+		var started, pending int
+		for len(queue) > 0 || pending > 0 {
+			var next task
+			var ch chan task
+			if len(queue) > 0 && (cr.opts.MaxPages <= 0 || started < cr.opts.MaxPages) {
+				next, ch = queue[0], tasks
+			} else if pending == 0 {
+				return
+			}
+			select {
+			case ch <- next:
+				queue = queue[1:]
+				started++
+				pending++
+			case r := <-results:
+				pending--
+				links := r.Links
+				r.Links = nil
+				for _, link := range links {
+					if !cr.inScope(link, hosts) {
+						continue
+					}
+					r.Links = append(r.Links, link)
+					if !seen[link] && (cr.opts.MaxDepth < 0 || r.Depth < cr.opts.MaxDepth) {
+						seen[link] = true
+						queue = append(queue, task{link, r.Depth + 1})
+					}
+				}
+				if !send(r) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
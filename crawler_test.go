@@ -0,0 +1,84 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	for _, tc := range []struct {
+		url    string
+		expect string
+	}{
+		{"HTTP://Example.com", "http://example.com/"},
+		{"https://example.com:443/a?b=2&a=1#frag", "https://example.com/a?a=1&b=2"},
+		{"http://example.com:8080/", "http://example.com:8080/"},
+	} {
+		if res, err := NormalizeURL(tc.url); err != nil {
+			t.Error(err)
+		} else if res != tc.expect {
+			t.Errorf("expected %q; got %q", tc.expect, res)
+		}
+	}
+	for _, url := range []string{"mailto:a@example.com", "/relative"} {
+		if _, err := NormalizeURL(url); err == nil {
+			t.Errorf("%s: expected error; got nil", url)
+		}
+	}
+}
+
+func TestCrawler(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<a href="/a">a</a><a href="/b#x">b</a><a href="https://example.com/">external</a>`)
+		case "/a":
+			fmt.Fprint(w, `<a href="/">home</a><a href="/c">c</a>`)
+		case "/b":
+			fmt.Fprint(w, `<a href="/a">a</a>`)
+		default:
+			fmt.Fprint(w, `<a href="/d">d</a>`)
+		}
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var handled atomic.Int32
+	crawler := c.NewCrawler(&CrawlerOptions{
+		MaxDepth:    1,
+		Exclude:     []any{ts.URL + "/c"},
+		Concurrency: 2,
+		Delay:       10 * time.Millisecond,
+		Handler: func(ctx context.Context, r *CrawlResult) error {
+			handled.Add(1)
+			return chromedp.Run(ctx, chromedp.WaitReady("body", chromedp.ByQuery))
+		},
+	})
+	var urls []string
+	for r := range crawler.Run(ctx, ts.URL, ts.URL+"/c") {
+		if r.Err != nil {
+			t.Errorf("%s: %v", r.URL, r.Err)
+		}
+		urls = append(urls, r.URL)
+	}
+	slices.Sort(urls)
+	if expect := []string{ts.URL + "/", ts.URL + "/a", ts.URL + "/b"}; !slices.Equal(urls, expect) {
+		t.Errorf("expected %v; got %v", expect, urls)
+	}
+	if n := handled.Load(); n != 3 {
+		t.Errorf("expected 3 handled pages; got %d", n)
+	}
+}
@@ -0,0 +1,110 @@
+package chrome
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// Dialog represents a JavaScript dialog opened by the page.
+type Dialog struct {
+	Type          page.DialogType // alert, confirm, prompt or beforeunload
+	Message       string          // Message shown in the dialog
+	DefaultPrompt string          // Default value of a prompt dialog
+	URL           string          // URL of the frame that opened the dialog
+	FrameID       cdp.FrameID     // Frame that opened the dialog
+}
+
+// DialogAction specifies how a dialog is closed.
+type DialogAction struct {
+	Accept     bool   // Accept the dialog instead of dismissing it
+	PromptText string // Text entered into a prompt dialog before accepting
+}
+
+// newDialog converts a dialog event.
+func newDialog(ev *page.EventJavascriptDialogOpening) *Dialog {
+	return &Dialog{
+		Type:          ev.Type,
+		Message:       ev.Message,
+		DefaultPrompt: ev.DefaultPrompt,
+		URL:           ev.URL,
+		FrameID:       ev.FrameID,
+	}
+}
+
+// AcceptAllDialogs accepts every dialog, keeping the default value of prompts.
+func AcceptAllDialogs(d *Dialog) DialogAction {
+	return DialogAction{Accept: true, PromptText: d.DefaultPrompt}
+}
+
+// DismissAllDialogs dismisses every dialog.
+func DismissAllDialogs(*Dialog) DialogAction {
+	return DialogAction{}
+}
+
+// AnswerPrompts returns a policy that answers prompt dialogs with text and accepts all other dialogs.
+func AnswerPrompts(text string) func(*Dialog) DialogAction {
+	return func(d *Dialog) DialogAction {
+		if d.Type == page.DialogTypePrompt {
+			return DialogAction{Accept: true, PromptText: text}
+		}
+		return AcceptAllDialogs(d)
+	}
+}
+
+// LogAndAcceptDialogs returns a policy that logs every dialog to logger and accepts it.
+// A nil logger uses slog.Default.
+func LogAndAcceptDialogs(logger *slog.Logger) func(*Dialog) DialogAction {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(d *Dialog) DialogAction {
+		logger.Info("javascript dialog", "type", d.Type, "message", d.Message, "url", d.URL)
+		return AcceptAllDialogs(d)
+	}
+}
+
+// HandleDialogs closes every JavaScript dialog opened by the page with the action returned by fn
+// until ctx is done.
+func HandleDialogs(ctx context.Context, fn func(*Dialog) DialogAction) {
+	chromedp.ListenTarget(ctx, func(v any) {
+		if ev, ok := v.(*page.EventJavascriptDialogOpening); ok {
+			go func() {
+				// The target is looked up here, as it is not attached yet on a context that has not been run.
+				executor := cdp.WithExecutor(ctx, chromedp.FromContext(ctx).Target)
+				action := fn(newDialog(ev))
+				params := page.HandleJavaScriptDialog(action.Accept)
+				if action.PromptText != "" {
+					params = params.WithPromptText(action.PromptText)
+				}
+				if err := params.Do(executor); err != nil {
+					slog.Debug(err.Error())
+				}
+			}()
+		}
+	})
+}
+
+// ListenDialogs listens for JavaScript dialogs opened by the page.
+// Dialogs are delivered in order until ctx is done. They are not closed; use HandleDialogs for that.
+func ListenDialogs(ctx context.Context) <-chan *Dialog {
+	return listenTarget(ctx, func(v any) (*Dialog, bool) {
+		if ev, ok := v.(*page.EventJavascriptDialogOpening); ok {
+			return newDialog(ev), true
+		}
+		return nil, false
+	})
+}
+
+// HandleDialogs closes JavaScript dialogs opened by the page of this Chrome instance with fn.
+func (c *Chrome) HandleDialogs(fn func(*Dialog) DialogAction) {
+	HandleDialogs(c, fn)
+}
+
+// ListenDialogs listens for JavaScript dialogs opened by the page of this Chrome instance.
+func (c *Chrome) ListenDialogs() <-chan *Dialog {
+	return ListenDialogs(c)
+}
@@ -0,0 +1,58 @@
+package chrome
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+func TestDialogPolicies(t *testing.T) {
+	prompt := &Dialog{Type: page.DialogTypePrompt, DefaultPrompt: "default"}
+	alert := &Dialog{Type: page.DialogTypeAlert}
+	for _, tc := range []struct {
+		action DialogAction
+		expect DialogAction
+	}{
+		{AcceptAllDialogs(prompt), DialogAction{true, "default"}},
+		{DismissAllDialogs(prompt), DialogAction{}},
+		{AnswerPrompts("answer")(prompt), DialogAction{true, "answer"}},
+		{AnswerPrompts("answer")(alert), DialogAction{true, ""}},
+	} {
+		if tc.action != tc.expect {
+			t.Errorf("expected %v; got %v", tc.expect, tc.action)
+		}
+	}
+}
+
+func TestHandleDialogs(t *testing.T) {
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	dialogs := ListenDialogs(ctx)
+	HandleDialogs(ctx, AnswerPrompts("answer"))
+
+	var res string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(`alert("hello"); prompt("name?")`, &res)); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "answer"; res != expect {
+		t.Errorf("expected %q; got %q", expect, res)
+	}
+
+	for _, expect := range []string{"hello", "name?"} {
+		select {
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		case d := <-dialogs:
+			if d.Message != expect {
+				t.Errorf("expected %q; got %q", expect, d.Message)
+			}
+		}
+	}
+}
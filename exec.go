@@ -0,0 +1,191 @@
+package chrome
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrExecNotFound is returned when no browser executable can be found.
+var ErrExecNotFound = errors.New("chrome executable not found")
+
+// BrowsersDir is the local directory searched for installed browser builds.
+// Each build lives in a subdirectory named after its version, e.g. BrowsersDir/126.0.6478.126.
+var BrowsersDir = defaultBrowsersDir()
+
+// defaultBrowsersDir returns the default local browsers directory under the user cache directory.
+func defaultBrowsersDir() string {
+	if dir := os.Getenv("CHROME_BROWSERS_DIR"); dir != "" {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "chrome", "browsers")
+}
+
+// installLocations returns common browser install locations for the current OS.
+func installLocations() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+			"/Applications/Google Chrome Beta.app/Contents/MacOS/Google Chrome Beta",
+			"/Applications/Google Chrome Canary.app/Contents/MacOS/Google Chrome Canary",
+		}
+	case "windows":
+		return []string{
+			"chrome",
+			"chrome.exe",
+			filepath.Join(os.Getenv("ProgramFiles"), `Google\Chrome\Application\chrome.exe`),
+			filepath.Join(os.Getenv("ProgramFiles(x86)"), `Google\Chrome\Application\chrome.exe`),
+			filepath.Join(os.Getenv("LocalAppData"), `Google\Chrome\Application\chrome.exe`),
+			filepath.Join(os.Getenv("LocalAppData"), `Chromium\Application\chrome.exe`),
+		}
+	default:
+		return []string{
+			"google-chrome",
+			"google-chrome-stable",
+			"chromium",
+			"chromium-browser",
+			"google-chrome-beta",
+			"google-chrome-unstable",
+			"headless_shell",
+			"headless-shell",
+			"chrome",
+			"/usr/bin/google-chrome",
+			"/usr/local/bin/chrome",
+			"/snap/bin/chromium",
+			"/opt/google/chrome/chrome",
+		}
+	}
+}
+
+// buildExecutables returns executable paths, relative to a version directory, that a browser build may contain.
+func buildExecutables() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{
+			"Chromium.app/Contents/MacOS/Chromium",
+			"Google Chrome.app/Contents/MacOS/Google Chrome",
+			"chrome-mac-arm64/Google Chrome for Testing.app/Contents/MacOS/Google Chrome for Testing",
+			"chrome-mac-x64/Google Chrome for Testing.app/Contents/MacOS/Google Chrome for Testing",
+			"chrome-mac/Chromium.app/Contents/MacOS/Chromium",
+			"chrome-headless-shell-mac-arm64/chrome-headless-shell",
+			"chrome-headless-shell-mac-x64/chrome-headless-shell",
+		}
+	case "windows":
+		return []string{
+			"chrome.exe",
+			"chrome-win64/chrome.exe",
+			"chrome-win32/chrome.exe",
+			"chrome-win/chrome.exe",
+			"chrome-headless-shell-win64/chrome-headless-shell.exe",
+			"chrome-headless-shell-win32/chrome-headless-shell.exe",
+		}
+	default:
+		return []string{
+			"chrome",
+			"chromium",
+			"headless_shell",
+			"chrome-linux64/chrome",
+			"chrome-linux/chrome",
+			"chrome-headless-shell-linux64/chrome-headless-shell",
+		}
+	}
+}
+
+// isExecutable reports whether path refers to an executable regular file.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0111 != 0
+}
+
+// compareVersion compares two dotted version strings numerically.
+func compareVersion(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := range max(len(as), len(bs)) {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// matchVersion checks if an installed version satisfies the pinned version.
+// A pin matches whole dotted components, so "126" matches "126.0.6478.126" but not "1260.0".
+func matchVersion(installed, pin string) bool {
+	return installed == pin || strings.HasPrefix(installed, pin+".")
+}
+
+// findLocalExecPath searches dir for a browser build matching version.
+// If version is empty, the newest build is used.
+func findLocalExecPath(dir, version string) (string, error) {
+	if dir == "" {
+		return "", ErrExecNotFound
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var versions []string
+	for _, i := range entries {
+		if i.IsDir() && (version == "" || matchVersion(i.Name(), version)) {
+			versions = append(versions, i.Name())
+		}
+	}
+	slices.SortFunc(versions, func(a, b string) int { return compareVersion(b, a) })
+	for _, v := range versions {
+		for _, name := range buildExecutables() {
+			if path := filepath.Join(dir, v, filepath.FromSlash(name)); isExecutable(path) {
+				return path, nil
+			}
+		}
+	}
+	return "", ErrExecNotFound
+}
+
+// FindExecPath searches for a browser executable.
+// It checks, in order, the CHROME_PATH environment variable, the local BrowsersDir and common install locations.
+// If version is not empty, only builds in BrowsersDir whose version matches it are considered.
+func FindExecPath(version string) (string, error) {
+	if path := os.Getenv("CHROME_PATH"); path != "" {
+		found, err := exec.LookPath(path)
+		if err != nil {
+			return "", fmt.Errorf("CHROME_PATH: %w", err)
+		}
+		return found, nil
+	}
+	if path, err := findLocalExecPath(BrowsersDir, version); err == nil {
+		return path, nil
+	} else if version != "" {
+		return "", fmt.Errorf("version %s in %q: %w", version, BrowsersDir, ErrExecNotFound)
+	}
+	for _, path := range installLocations() {
+		if found, err := exec.LookPath(path); err == nil {
+			return found, nil
+		}
+	}
+	return "", ErrExecNotFound
+}
@@ -0,0 +1,67 @@
+package chrome
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestFindExecPath(t *testing.T) {
+	dir := t.TempDir()
+	name := buildExecutables()[0]
+	for _, version := range []string{"120.0.6099.109", "126.0.6478.126", "126.0.6478.61"} {
+		path := filepath.Join(dir, version, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer func(dir string) { BrowsersDir = dir }(BrowsersDir)
+	BrowsersDir = dir
+	t.Setenv("CHROME_PATH", "")
+
+	testcases := []struct {
+		version string
+		expect  string
+	}{
+		{"", "126.0.6478.126"},
+		{"126", "126.0.6478.126"},
+		{"126.0.6478.61", "126.0.6478.61"},
+		{"120", "120.0.6099.109"},
+	}
+	for _, tc := range testcases {
+		path, err := FindExecPath(tc.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect := filepath.Join(dir, tc.expect, filepath.FromSlash(name)); path != expect {
+			t.Errorf("version %q: expected %q; got %q", tc.version, expect, path)
+		}
+	}
+	if _, err := FindExecPath("12"); !errors.Is(err, ErrExecNotFound) {
+		t.Errorf("expected ErrExecNotFound; got %v", err)
+	}
+
+	c := New("").BrowserVersion("12")
+	if _, _, err := c.NewContext(); !errors.Is(err, ErrExecNotFound) {
+		t.Errorf("expected ErrExecNotFound; got %v", err)
+	}
+	if c.ctx != nil {
+		t.Error("expected no browser context after a failed lookup")
+	}
+	c.Close()
+
+	if runtime.GOOS != "windows" {
+		expect := filepath.Join(dir, "120.0.6099.109", filepath.FromSlash(name))
+		t.Setenv("CHROME_PATH", expect)
+		if path, err := FindExecPath("126"); err != nil {
+			t.Fatal(err)
+		} else if path != expect {
+			t.Errorf("expected %q; got %q", expect, path)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package chrome
 
 import (
 	"context"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/cdproto/fetch"
@@ -9,23 +12,228 @@ import (
 	"github.com/chromedp/chromedp"
 )
 
+// fetchUpdateTimeout bounds updating the interception patterns after a handler is removed,
+// as the tab may already be closed.
+const fetchUpdateTimeout = 5 * time.Second
+
+// fetchHandler is a handler registered with HandleFetch, or a filter registered with filterFetch.
+type fetchHandler struct {
+	ctx      context.Context
+	fn       func(context.Context, *fetch.EventRequestPaused) error
+	filter   func(context.Context, *fetch.EventRequestPaused) (bool, error)
+	patterns []*fetch.RequestPattern
+}
+
+// fetchDispatcher enables Fetch interception on a tab with the patterns of all its handlers
+// and routes each paused request to a single handler.
+type fetchDispatcher struct {
+	target *chromedp.Target
+	ctx    context.Context // executes commands on the tab until the last handler is removed
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	handlers []*fetchHandler
+
+	enableMu sync.Mutex
+}
+
+// fetchEscaper escapes the wildcard characters of Fetch URL patterns.
+var fetchEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`)
+
+var (
+	fetchMu          sync.Mutex
+	fetchDispatchers = make(map[*chromedp.Target]*fetchDispatcher)
+)
+
+// fetchMatch reports whether s matches a Fetch URL pattern, where * matches any run of characters,
+// ? matches one character and \ escapes the next character.
+func fetchMatch(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(s); i >= 0; i-- {
+				if fetchMatch(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return len(s) == 0
+}
+
+// match reports whether the handler intercepts the paused request.
+func (h *fetchHandler) match(ev *fetch.EventRequestPaused) bool {
+	stage := fetch.RequestStageRequest
+	if ev.ResponseStatusCode != 0 || ev.ResponseErrorReason != "" {
+		stage = fetch.RequestStageResponse
+	}
+	for _, p := range h.patterns {
+		if (p.RequestStage == stage || (p.RequestStage == "" && stage == fetch.RequestStageRequest)) &&
+			(p.ResourceType == "" || p.ResourceType == ev.ResourceType) &&
+			fetchMatch(p.URLPattern, ev.Request.URL) {
+			return true
+		}
+	}
+	return false
+}
+
+// dispatch runs the filters matching the request in the order they were added, then calls the most
+// recently added handler matching it, or continues it if none does.
+func (d *fetchDispatcher) dispatch(ev *fetch.EventRequestPaused) {
+	d.mu.Lock()
+	var filters []*fetchHandler
+	var h *fetchHandler
+	for _, handler := range d.handlers {
+		if handler.filter != nil && handler.match(ev) {
+			filters = append(filters, handler)
+		}
+	}
+	for i := len(d.handlers) - 1; i >= 0; i-- {
+		if d.handlers[i].filter == nil && d.handlers[i].match(ev) {
+			h = d.handlers[i]
+			break
+		}
+	}
+	d.mu.Unlock()
+	for _, f := range filters {
+		ctx := cdp.WithExecutor(f.ctx, d.target)
+		if ok, err := f.filter(ctx, ev); err != nil {
+			fetch.FailRequest(ev.RequestID, network.ErrorReasonFailed).Do(ctx)
+			return
+		} else if !ok {
+			return
+		}
+	}
+	if h == nil {
+		fetch.ContinueRequest(ev.RequestID).Do(d.ctx)
+		return
+	}
+	ctx := cdp.WithExecutor(h.ctx, d.target)
+	if err := h.fn(ctx, ev); err != nil {
+		fetch.FailRequest(ev.RequestID, network.ErrorReasonFailed).Do(ctx)
+	}
+}
+
+// update enables interception with the patterns of all handlers, or disables it if there are none.
+func (d *fetchDispatcher) update(ctx context.Context) error {
+	d.enableMu.Lock()
+	defer d.enableMu.Unlock()
+	d.mu.Lock()
+	var patterns []*fetch.RequestPattern
+	for _, h := range d.handlers {
+		patterns = append(patterns, h.patterns...)
+	}
+	d.mu.Unlock()
+	ctx = cdp.WithExecutor(ctx, d.target)
+	if len(patterns) == 0 {
+		return fetch.Disable().Do(ctx)
+	}
+	return fetch.Enable().WithPatterns(patterns).Do(ctx)
+}
+
+// remove unregisters a handler, releasing the dispatcher of the tab once no handler is left.
+// The registry stays locked while interception is updated, so that disabling it cannot
+// overtake a dispatcher created for the same tab afterwards.
+func (d *fetchDispatcher) remove(h *fetchHandler) {
+	fetchMu.Lock()
+	defer fetchMu.Unlock()
+	d.mu.Lock()
+	for i, handler := range d.handlers {
+		if handler == h {
+			d.handlers = append(d.handlers[:i:i], d.handlers[i+1:]...)
+			break
+		}
+	}
+	empty := len(d.handlers) == 0
+	d.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(d.ctx, fetchUpdateTimeout)
+	defer cancel()
+	d.update(ctx)
+	if empty {
+		if fetchDispatchers[d.target] == d {
+			delete(fetchDispatchers, d.target)
+		}
+		d.cancel()
+	}
+}
+
+// HandleFetch enables request interception using the Fetch API and calls fn for each paused request
+// matching patterns. The context passed to fn executes commands on the target, and fn is responsible
+// for continuing, fulfilling or failing the request. If fn returns an error, the request is failed.
+// Without patterns, all requests are intercepted at the request stage.
+//
+// Handlers of the same tab share a single interception: each paused request goes to the most
+// recently added handler whose patterns match it, and requests matched by none are continued.
+// Requests blocked by a NavigationGuard never reach a handler. A handler is removed when ctx is done.
+func HandleFetch(ctx context.Context, fn func(context.Context, *fetch.EventRequestPaused) error, patterns ...*fetch.RequestPattern) error {
+	return addFetchHandler(ctx, &fetchHandler{fn: fn, patterns: patterns})
+}
+
+// filterFetch adds a filter run for each paused request matching patterns before any handler of
+// the tab, regardless of the order they were added. The filter returns true to pass the request on;
+// otherwise it has continued, fulfilled or failed the request itself. If it returns an error, the
+// request is failed.
+func filterFetch(ctx context.Context, fn func(context.Context, *fetch.EventRequestPaused) (bool, error), patterns ...*fetch.RequestPattern) error {
+	return addFetchHandler(ctx, &fetchHandler{filter: fn, patterns: patterns})
+}
+
+// addFetchHandler registers h with the dispatcher of the tab of ctx until ctx is done.
+func addFetchHandler(ctx context.Context, h *fetchHandler) error {
+	if err := chromedp.Run(ctx); err != nil {
+		return err
+	}
+	if len(h.patterns) == 0 {
+		h.patterns = []*fetch.RequestPattern{{URLPattern: "*"}}
+	}
+	h.ctx = ctx
+	t := chromedp.FromContext(ctx).Target
+
+	fetchMu.Lock()
+	d, ok := fetchDispatchers[t]
+	if !ok {
+		dctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		d = &fetchDispatcher{target: t, ctx: cdp.WithExecutor(dctx, t), cancel: cancel}
+		fetchDispatchers[t] = d
+		chromedp.ListenTarget(dctx, func(v any) {
+			if ev, ok := v.(*fetch.EventRequestPaused); ok {
+				go d.dispatch(ev)
+			}
+		})
+	}
+	d.mu.Lock()
+	d.handlers = append(d.handlers, h)
+	d.mu.Unlock()
+	fetchMu.Unlock()
+
+	context.AfterFunc(ctx, func() { d.remove(h) })
+	return d.update(ctx)
+}
+
 // EnableFetch enables request/response interception using the Fetch API.
 // The provided function is called for each paused request and should return true to allow it or false to block it.
 func EnableFetch(ctx context.Context, fn func(*fetch.EventRequestPaused) bool) error {
-	chromedp.ListenTarget(ctx, func(v any) {
-		switch ev := v.(type) {
-		case *fetch.EventRequestPaused:
-			go func() {
-				ctx := cdp.WithExecutor(ctx, chromedp.FromContext(ctx).Target)
-				if fn(ev) {
-					fetch.ContinueRequest(ev.RequestID).Do(ctx)
-				} else {
-					fetch.FailRequest(ev.RequestID, network.ErrorReasonBlockedByClient).Do(ctx)
-				}
-			}()
+	return HandleFetch(ctx, func(ctx context.Context, ev *fetch.EventRequestPaused) error {
+		if fn(ev) {
+			return fetch.ContinueRequest(ev.RequestID).Do(ctx)
 		}
+		return fetch.FailRequest(ev.RequestID, network.ErrorReasonBlockedByClient).Do(ctx)
 	})
-	return chromedp.Run(ctx, fetch.Enable())
 }
 
 // EnableFetch enables request/response interception on this Chrome instance.
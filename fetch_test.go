@@ -0,0 +1,116 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+func TestFetchMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, url string
+		expect       bool
+	}{
+		{"*", "https://example.com/", true},
+		{"https://example.com/*", "https://example.com/a/b", true},
+		{"https://example.com/*", "https://example.org/", false},
+		{"https://example.com/?", "https://example.com/a", true},
+		{"https://example.com/?", "https://example.com/ab", false},
+		{`https://example.com/\?a`, "https://example.com/?a", true},
+		{`https://example.com/\?a`, "https://example.com/xa", false},
+		{"*.png", "https://example.com/a.png", true},
+	} {
+		if res := fetchMatch(tc.pattern, tc.url); res != tc.expect {
+			t.Errorf("%s %s: expected %v; got %v", tc.pattern, tc.url, tc.expect, res)
+		}
+	}
+
+	h := &fetchHandler{patterns: []*fetch.RequestPattern{{URLPattern: "*", ResourceType: network.ResourceTypeDocument}}}
+	ev := &fetch.EventRequestPaused{Request: &network.Request{URL: "https://example.com/"}, ResourceType: network.ResourceTypeDocument}
+	if !h.match(ev) {
+		t.Error("expected document request to match")
+	}
+	if ev.ResourceType = network.ResourceTypeImage; h.match(ev) {
+		t.Error("expected image request not to match")
+	}
+	if ev.ResourceType, ev.ResponseStatusCode = network.ResourceTypeDocument, 200; h.match(ev) {
+		t.Error("expected response stage not to match request stage pattern")
+	}
+}
+
+func TestHandleFetch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<!DOCTYPE html><html><body>network</body></html>")
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	var blocked, served atomic.Int32
+	if err := EnableFetch(ctx, func(ev *fetch.EventRequestPaused) bool {
+		blocked.Add(1)
+		return ev.Request.URL != ts.URL+"/blocked"
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ServeFS(ctx, "http://fetch.localhost/", fstest.MapFS{"index.html": {Data: []byte("fs")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var text string
+	if err := chromedp.Run(ctx, chromedp.Navigate("http://fetch.localhost/"), chromedp.Text("body", &text)); err != nil {
+		t.Fatal(err)
+	} else if text != "fs" {
+		t.Errorf("expected fs; got %q", text)
+	}
+	var missing string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(`fetch("/missing.css").then(r => r.status + " " + r.headers.get("Content-Type"))`, &missing, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	})); err != nil {
+		t.Fatal(err)
+	} else if expect := "404 text/css; charset=utf-8"; missing != expect {
+		t.Errorf("expected %q; got %q", expect, missing)
+	}
+	if n := blocked.Load(); n != 0 {
+		t.Errorf("expected ServeFS request not to reach EnableFetch handler; got %d", n)
+	}
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL+"/blocked")); err == nil {
+		t.Error("expected blocked navigation")
+	}
+
+	// The most recently added matching handler takes the request.
+	hctx, hcancel := context.WithCancel(ctx)
+	if err := HandleFetch(hctx, func(ctx context.Context, ev *fetch.EventRequestPaused) error {
+		served.Add(1)
+		return fetch.ContinueRequest(ev.RequestID).Do(ctx)
+	}, &fetch.RequestPattern{URLPattern: ts.URL + "/handled*"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL+"/handled")); err != nil {
+		t.Fatal(err)
+	}
+	hcancel()
+	if n := served.Load(); n != 1 {
+		t.Errorf("expected 1 request handled; got %d", n)
+	}
+	if n := blocked.Load(); n < 2 {
+		t.Errorf("expected at least 2 requests for EnableFetch handler; got %d", n)
+	}
+}
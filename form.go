@@ -0,0 +1,254 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/dom"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// formFieldsScript defines fields, returning the elements of a form named name, or else with that id.
+const formFieldsScript = `
+	const fields = (root, name) => {
+		const els = Array.from(root.querySelectorAll("[name]")).filter(el => el.name === name);
+		return els.length ? els : Array.from(root.querySelectorAll("#" + CSS.escape(name)));
+	};`
+
+// fillFormScript sets the values of named form fields and fires the events a user would.
+// It returns the names of missing fields and of file inputs, which are set through the protocol.
+const fillFormScript = `(form, values) => {` + formFieldsScript + `
+	const root = document.querySelector(form);
+	if (!root) throw new Error("form not found: " + form);
+	const fire = el => {
+		el.dispatchEvent(new Event("input", { bubbles: true }));
+		el.dispatchEvent(new Event("change", { bubbles: true }));
+	};
+	const setValue = (el, value) => {
+		const proto = el instanceof HTMLTextAreaElement ? HTMLTextAreaElement.prototype :
+			el instanceof HTMLSelectElement ? HTMLSelectElement.prototype : HTMLInputElement.prototype;
+		Object.getOwnPropertyDescriptor(proto, "value").set.call(el, value);
+	};
+	const formatTime = (type, t) => {
+		switch (type) {
+			case "date": return t.slice(0, 10);
+			case "month": return t.slice(0, 7);
+			case "time": return t.slice(11);
+			default: return t;
+		}
+	};
+	const result = { missing: [], files: [] };
+	fields: for (const [name, value] of Object.entries(values)) {
+		const els = fields(root, name);
+		if (!els.length) {
+			result.missing.push(name);
+			continue;
+		}
+		const list = Array.isArray(value) ? value.map(String) : [String(value)];
+		for (const el of els) {
+			const type = el instanceof HTMLInputElement ? el.type : el.tagName.toLowerCase();
+			switch (type) {
+				case "file":
+					result.files.push(name);
+					continue fields;
+				case "checkbox":
+					if (el.checked !== (typeof value === "boolean" ? value : list.includes(el.value))) el.click();
+					break;
+				case "radio":
+					if (!el.checked && list.includes(el.value)) el.click();
+					break;
+				case "select":
+					if (el.multiple) Array.from(el.options).forEach(o => o.selected = list.includes(o.value));
+					else setValue(el, list[0]);
+					fire(el);
+					break;
+				default:
+					setValue(el, value && value.$time ? formatTime(type, value.$time) : list.join(","));
+					fire(el);
+			}
+		}
+	}
+	return result;
+}`
+
+// fileInputScript returns the file input of a form found like in fillFormScript.
+const fileInputScript = `(form, name) => {` + formFieldsScript + `
+	const root = document.querySelector(form);
+	return root && fields(root, name).find(el => el instanceof HTMLInputElement && el.type === "file");
+}`
+
+// formValue converts a field value for fillFormScript.
+func formValue(v any) any {
+	if t, ok := v.(time.Time); ok {
+		return map[string]string{"$time": t.Format("2006-01-02T15:04:05")}
+	}
+	return v
+}
+
+// formPaths returns the file paths of a file input value.
+func formPaths(v any) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported file input value: %T", v)
+	}
+}
+
+// FillForm sets the fields of the form selected by formSelector from values keyed by field name or id.
+// Text-like inputs, textareas and selects are set to the value and receive input and change events;
+// date and time inputs also accept time.Time values. Checkboxes take a bool or the values to check,
+// radio buttons the value to select, multiple selects and checkbox groups a []string, and file
+// inputs one or more paths. Missing fields are reported in the returned error.
+func FillForm(ctx context.Context, formSelector string, values map[string]any) error {
+	args := make(map[string]any, len(values))
+	for k, v := range values {
+		args[k] = formValue(v)
+	}
+	b, err := json.Marshal([]any{formSelector, args})
+	if err != nil {
+		return err
+	}
+	var res struct {
+		Missing []string `json:"missing"`
+		Files   []string `json:"files"`
+	}
+	if err := chromedp.Run(ctx, FrameEvaluate(fmt.Sprintf("(%s)(...%s)", fillFormScript, b), &res)); err != nil {
+		return err
+	}
+	if len(res.Missing) > 0 {
+		return fmt.Errorf("form fields not found: %s", strings.Join(res.Missing, ", "))
+	}
+	for _, name := range res.Files {
+		paths, err := formPaths(values[name])
+		if err != nil {
+			return err
+		}
+		if b, err = json.Marshal([]string{formSelector, name}); err != nil {
+			return err
+		}
+		if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			var input *runtime.RemoteObject
+			if err := FrameEvaluate(fmt.Sprintf("(%s)(...%s)", fileInputScript, b), &input).Do(ctx); err != nil {
+				return err
+			}
+			if input == nil || input.ObjectID == "" {
+				return fmt.Errorf("file input not found: %s", name)
+			}
+			defer runtime.ReleaseObject(input.ObjectID).Do(ctx)
+			return setInputFiles(ctx, input.ObjectID, paths)
+		})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// absPaths resolves paths against the working directory.
+func absPaths(paths []string) ([]string, error) {
+	files := make([]string, len(paths))
+	for i, path := range paths {
+		var err error
+		if files[i], err = filepath.Abs(path); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// setInputFiles sets the files of the file input with the remote object ID.
+func setInputFiles(ctx context.Context, id runtime.RemoteObjectID, paths []string) error {
+	files, err := absPaths(paths)
+	if err != nil {
+		return err
+	}
+	return dom.SetFileInputFiles(files).WithObjectID(id).Do(ctx)
+}
+
+// UploadFiles sets the files of the file input selected by selector using DOM.setFileInputFiles.
+// Relative paths are resolved against the working directory.
+func UploadFiles(ctx context.Context, selector string, paths ...string) error {
+	files, err := absPaths(paths)
+	if err != nil {
+		return err
+	}
+	return chromedp.Run(ctx, chromedp.SetUploadFiles(selector, files, chromedp.ByQuery, FrameQuery(ctx)))
+}
+
+// SubmitForm submits the form selected by formSelector as if its submit button was clicked,
+// running validation and submit handlers, and waits until the resulting navigation has loaded.
+// With a context returned by Frame, the navigation of that frame is waited for.
+// Forms whose submission does not navigate the frame block until ctx is done.
+func SubmitForm(ctx context.Context, formSelector string) error {
+	return chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var frameID cdp.FrameID
+		if owner, ok := frameOwner(ctx); ok {
+			frameID = owner.FrameID
+		} else {
+			var err error
+			if frameID, err = mainFrame(ctx); err != nil {
+				return err
+			}
+		}
+
+		lctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		loaded := make(chan struct{})
+		var navigated, done bool
+		chromedp.ListenTarget(lctx, func(v any) {
+			switch ev := v.(type) {
+			case *page.EventFrameNavigated:
+				if ev.Frame.ID == frameID {
+					navigated = true
+				}
+			case *page.EventFrameStoppedLoading:
+				if ev.FrameID == frameID && navigated && !done {
+					done = true
+					close(loaded)
+				}
+			}
+		})
+
+		b, err := json.Marshal(formSelector)
+		if err != nil {
+			return err
+		}
+		if err := FrameEvaluate(fmt.Sprintf(`(form => {
+	const el = document.querySelector(form);
+	if (!el) throw new Error("form not found: " + form);
+	el.requestSubmit();
+})(%s)`, b), nil).Do(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-loaded:
+			return nil
+		}
+	}))
+}
+
+// FillForm sets the fields of a form in the page of this Chrome instance.
+func (c *Chrome) FillForm(formSelector string, values map[string]any) error {
+	return FillForm(c, formSelector, values)
+}
+
+// UploadFiles sets the files of a file input in the page of this Chrome instance.
+func (c *Chrome) UploadFiles(selector string, paths ...string) error {
+	return UploadFiles(c, selector, paths...)
+}
+
+// SubmitForm submits a form in the page of this Chrome instance and waits for the navigation.
+func (c *Chrome) SubmitForm(formSelector string) error {
+	return SubmitForm(c, formSelector)
+}
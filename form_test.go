@@ -0,0 +1,88 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+const testForm = `<!DOCTYPE html><html><body>
+<form id="form" method="post" action="/submit" enctype="multipart/form-data">
+<input name="text">
+<select name="select"><option value="a">A</option><option value="b">B</option></select>
+<input type="checkbox" name="check" value="on">
+<input type="radio" name="radio" value="x"><input type="radio" name="radio" value="y">
+<input type="date" name="date">
+<input type="file" id="upload" name="file">
+</form>
+</body></html>`
+
+func TestFillForm(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/submit" {
+			fmt.Fprint(w, testForm)
+			return
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var content string
+		if f, _, err := r.FormFile("file"); err == nil {
+			b, _ := io.ReadAll(f)
+			content = string(b)
+		}
+		fmt.Fprintf(w, "<!DOCTYPE html><html><body><p id=\"result\">%s|%s|%s|%s|%s|%s</p></body></html>",
+			r.FormValue("text"), r.FormValue("select"), r.FormValue("check"), r.FormValue("radio"), r.FormValue("date"), content)
+	}))
+	defer ts.Close()
+
+	file := filepath.Join(t.TempDir(), "upload.txt")
+	if err := os.WriteFile(file, []byte("uploaded"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	// The file input is found by id, in a form selected by a selector list.
+	if err := FillForm(ctx, "#other, #form", map[string]any{
+		"text":   "hello",
+		"select": "b",
+		"check":  true,
+		"radio":  "y",
+		"date":   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		"upload": file,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := FillForm(ctx, "#form", map[string]any{"missing": "x"}); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected missing field error; got %v", err)
+	}
+	if err := SubmitForm(ctx, "#form"); err != nil {
+		t.Fatal(err)
+	}
+
+	var result string
+	if err := chromedp.Run(ctx, chromedp.Text("#result", &result, chromedp.ByQuery)); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "hello|b|on|y|2024-01-02|uploaded"; result != expect {
+		t.Errorf("expected %q; got %q", expect, result)
+	}
+}
@@ -0,0 +1,196 @@
+package chrome
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/dom"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// ErrFrameNotFound is returned when no frame matches.
+var ErrFrameNotFound = errors.New("frame not found")
+
+// FrameName matches frames by the name attribute of their owner element.
+type FrameName string
+
+// FrameSelector matches the frame owned by the iframe or frame element selected by a CSS selector.
+type FrameSelector string
+
+// frameKey is the context key of the owner element of an in-process frame.
+type frameKey struct{}
+
+// frameOwner returns the owner element of the frame a context is scoped to.
+func frameOwner(ctx context.Context) (*cdp.Node, bool) {
+	node, ok := ctx.Value(frameKey{}).(*cdp.Node)
+	return node, ok
+}
+
+// FrameQuery returns a query option scoping chromedp queries to the frame ctx was returned for by Frame.
+// It has no effect for other contexts, including contexts attached to out-of-process iframes.
+func FrameQuery(ctx context.Context) chromedp.QueryOption {
+	if node, ok := frameOwner(ctx); ok {
+		return chromedp.FromNode(node)
+	}
+	return func(*chromedp.Selector) {}
+}
+
+// FrameEvaluate returns an action evaluating expression in the main world of the frame
+// the run context was returned for by Frame, or of the document of the target for other contexts.
+// In both cases the expression is evaluated with Runtime.callFunctionOn, to which opts apply.
+func FrameEvaluate(expression string, res any, opts ...chromedp.CallOption) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		var backendNodeID cdp.BackendNodeID
+		if owner, ok := frameOwner(ctx); ok {
+			node, err := dom.DescribeNode().WithBackendNodeID(owner.BackendNodeID).WithPierce(true).Do(ctx)
+			if err != nil {
+				return err
+			}
+			if node.ContentDocument == nil {
+				return ErrFrameNotFound
+			}
+			backendNodeID = node.ContentDocument.BackendNodeID
+		} else {
+			doc, err := dom.GetDocument().WithDepth(0).Do(ctx)
+			if err != nil {
+				return err
+			}
+			backendNodeID = doc.BackendNodeID
+		}
+		doc, err := dom.ResolveNode().WithBackendNodeID(backendNodeID).Do(ctx)
+		if err != nil {
+			return err
+		}
+		defer runtime.ReleaseObject(doc.ObjectID).Do(ctx)
+		return chromedp.CallFunctionOn(`function(expression) { return globalThis.eval(expression) }`, res,
+			func(p *runtime.CallFunctionOnParams) *runtime.CallFunctionOnParams {
+				p = p.WithObjectID(doc.ObjectID)
+				for _, o := range opts {
+					p = o(p)
+				}
+				return p
+			},
+			expression,
+		).Do(ctx)
+	})
+}
+
+// findFrame returns the first frame of the tree below the main frame that satisfies fn.
+func findFrame(tree *page.FrameTree, fn func(*cdp.Frame) bool) *cdp.Frame {
+	for _, child := range tree.ChildFrames {
+		if fn(child.Frame) {
+			return child.Frame
+		}
+		if f := findFrame(child, fn); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// Frame resolves a frame of the page and returns a context scoped to it, and a function releasing it.
+// The matcher is a FrameName, a FrameSelector, or a URL matcher accepted by ListenEvent.
+//
+// Out-of-process iframes are attached as targets, so all chromedp actions run with the returned
+// context execute inside the frame. In-process frames share the page's target, whose chromedp
+// queries and Evaluate always act on the main frame and cannot be redirected by a context: with
+// the returned context, queries need FrameQuery(ctx) and scripts need FrameEvaluate instead of
+// chromedp.Evaluate. Both also work for out-of-process iframes, so code using them does not
+// depend on how the browser isolates the frame. The scope is tied to the frame's owner element and follows
+// navigations of the frame. In-process frames nested in other frames are resolved by calling Frame
+// with the parent frame's context.
+func Frame(ctx context.Context, matcher any) (context.Context, context.CancelFunc, error) {
+	var owner *cdp.Node
+	var frameID cdp.FrameID
+	var oopif bool
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		tree, err := page.GetFrameTree().Do(ctx)
+		if err != nil {
+			return err
+		}
+		frames := map[cdp.FrameID]bool{tree.Frame.ID: true}
+		findFrame(tree, func(f *cdp.Frame) bool {
+			frames[f.ID] = true
+			return false
+		})
+		targets, err := target.GetTargets().Do(cdp.WithExecutor(ctx, chromedp.FromContext(ctx).Browser))
+		if err != nil {
+			return err
+		}
+		iframes := make(map[cdp.FrameID]*target.Info)
+		for _, i := range targets {
+			if i.Type == "iframe" && frames[i.ParentFrameID] {
+				iframes[cdp.FrameID(i.TargetID)] = i
+			}
+		}
+
+		switch m := matcher.(type) {
+		case FrameSelector:
+			var nodes []*cdp.Node
+			if err := chromedp.Nodes(string(m), &nodes, chromedp.ByQueryAll, chromedp.AtLeast(0), FrameQuery(ctx)).Do(ctx); err != nil {
+				return err
+			}
+			if len(nodes) == 0 || nodes[0].FrameID == "" {
+				return ErrFrameNotFound
+			}
+			owner, frameID = nodes[0], nodes[0].FrameID
+		default:
+			f := findFrame(tree, func(f *cdp.Frame) bool {
+				if name, ok := m.(FrameName); ok {
+					return f.Name == string(name)
+				}
+				return match(f.URL+f.URLFragment, m)
+			})
+			if f != nil {
+				frameID = f.ID
+			} else if _, ok := m.(FrameName); !ok {
+				for id, i := range iframes {
+					if match(i.URL, m) {
+						frameID = id
+						break
+					}
+				}
+			}
+			if frameID == "" {
+				return ErrFrameNotFound
+			}
+		}
+		if _, oopif = iframes[frameID]; oopif || owner != nil {
+			return nil
+		}
+
+		var nodes []*cdp.Node
+		if err := chromedp.Nodes("iframe, frame", &nodes, chromedp.ByQueryAll, chromedp.AtLeast(0), FrameQuery(ctx)).Do(ctx); err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if node.FrameID == frameID {
+				owner = node
+				return nil
+			}
+		}
+		return ErrFrameNotFound
+	})); err != nil {
+		return nil, nil, err
+	}
+
+	if oopif {
+		fctx, cancel := chromedp.NewContext(ctx, chromedp.WithTargetID(target.ID(frameID)))
+		if err := chromedp.Run(fctx); err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		return fctx, cancel, nil
+	}
+	fctx, cancel := context.WithCancel(context.WithValue(ctx, frameKey{}, owner))
+	return fctx, cancel, nil
+}
+
+// Frame resolves a frame of the page of this Chrome instance and returns a context scoped to it.
+func (c *Chrome) Frame(matcher any) (context.Context, context.CancelFunc, error) {
+	return Frame(c, matcher)
+}
@@ -0,0 +1,124 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+func TestFrame(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/frame" {
+			fmt.Fprint(w, `<!DOCTYPE html><html><body><p id="text">inside</p><script>var value = "frame"</script></body></html>`)
+			return
+		}
+		fmt.Fprint(w, `<!DOCTYPE html><html><body><p id="text">outside</p><iframe id="inner" name="inner" src="/frame"></iframe></body></html>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, matcher := range []any{
+		FrameName("inner"),
+		FrameSelector("#inner"),
+		URLHasSuffix("/frame"),
+	} {
+		fctx, fcancel, err := Frame(ctx, matcher)
+		if err != nil {
+			t.Fatalf("%v: %v", matcher, err)
+		}
+		var text, value string
+		err = chromedp.Run(fctx,
+			chromedp.Text("#text", &text, chromedp.ByQuery, FrameQuery(fctx)),
+			FrameEvaluate("value", &value),
+		)
+		fcancel()
+		if err != nil {
+			t.Fatalf("%v: %v", matcher, err)
+		}
+		if expect := "inside"; text != expect {
+			t.Errorf("%v: expected %q; got %q", matcher, expect, text)
+		}
+		if expect := "frame"; value != expect {
+			t.Errorf("%v: expected %q; got %q", matcher, expect, value)
+		}
+	}
+
+	// Options apply in and outside of frames alike.
+	await := func(p *runtime.CallFunctionOnParams) *runtime.CallFunctionOnParams { return p.WithAwaitPromise(true) }
+	var text string
+	if err := chromedp.Run(ctx, FrameEvaluate(`Promise.resolve(document.getElementById("text").textContent)`, &text, await)); err != nil {
+		t.Fatal(err)
+	} else if expect := "outside"; text != expect {
+		t.Errorf("expected %q; got %q", expect, text)
+	}
+
+	for _, matcher := range []any{FrameName("missing"), FrameSelector("#missing")} {
+		if _, _, err := Frame(ctx, matcher); err != ErrFrameNotFound {
+			t.Errorf("%v: expected ErrFrameNotFound; got %v", matcher, err)
+		}
+	}
+}
+
+func TestFrameOutOfProcess(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/frame" {
+			fmt.Fprint(w, `<!DOCTYPE html><html><body><p id="text">inside</p><script>var value = "frame"</script></body></html>`)
+			return
+		}
+		// localhost is a different site than 127.0.0.1, so the iframe is isolated in its own process.
+		fmt.Fprintf(w, `<!DOCTYPE html><html><body><p id="text">outside</p><iframe src="%s/frame"></iframe></body></html>`,
+			strings.Replace(ts.URL, "127.0.0.1", "localhost", 1))
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	fctx, fcancel, err := Frame(ctx, URLHasPrefix("http://localhost:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fcancel()
+	if chromedp.FromContext(fctx).Target == chromedp.FromContext(ctx).Target {
+		t.Fatal("expected the frame to be attached as its own target")
+	}
+
+	// Plain chromedp actions run inside the frame, as do the frame helpers.
+	var text, helperText, value string
+	if err := chromedp.Run(fctx,
+		chromedp.Text("#text", &text, chromedp.ByQuery),
+		chromedp.Text("#text", &helperText, chromedp.ByQuery, FrameQuery(fctx)),
+		FrameEvaluate("value", &value),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if text != "inside" || helperText != "inside" {
+		t.Errorf("expected %q; got %q and %q", "inside", text, helperText)
+	}
+	if expect := "frame"; value != expect {
+		t.Errorf("expected %q; got %q", expect, value)
+	}
+}
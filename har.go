@@ -0,0 +1,450 @@
+package chrome
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// HAR represents an HTTP Archive (HAR) 1.2 document.
+type HAR struct {
+	Log *HARLog `json:"log"`
+}
+
+// HARLog is the root of the exported data.
+type HARLog struct {
+	Version string      `json:"version"`
+	Creator *HARCreator `json:"creator"`
+	Entries []*HAREntry `json:"entries"`
+}
+
+// HARCreator describes the application that created the log.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry represents an exported HTTP request.
+type HAREntry struct {
+	StartedDateTime time.Time    `json:"startedDateTime"`
+	Time            float64      `json:"time"`
+	Request         *HARRequest  `json:"request"`
+	Response        *HARResponse `json:"response"`
+	Cache           struct{}     `json:"cache"`
+	Timings         *HARTimings  `json:"timings"`
+	ServerIPAddress string       `json:"serverIPAddress,omitempty"`
+	ResourceType    string       `json:"_resourceType,omitempty"`
+	Error           string       `json:"_error,omitempty"`
+}
+
+// HARRequest contains detailed info about a performed request.
+type HARRequest struct {
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []*HARCookie    `json:"cookies"`
+	Headers     []*HARNameValue `json:"headers"`
+	QueryString []*HARNameValue `json:"queryString"`
+	PostData    *HARPostData    `json:"postData,omitempty"`
+	HeadersSize int64           `json:"headersSize"`
+	BodySize    int64           `json:"bodySize"`
+}
+
+// HARResponse contains detailed info about a response.
+type HARResponse struct {
+	Status       int64           `json:"status"`
+	StatusText   string          `json:"statusText"`
+	HTTPVersion  string          `json:"httpVersion"`
+	Cookies      []*HARCookie    `json:"cookies"`
+	Headers      []*HARNameValue `json:"headers"`
+	Content      *HARContent     `json:"content"`
+	RedirectURL  string          `json:"redirectURL"`
+	HeadersSize  int64           `json:"headersSize"`
+	BodySize     int64           `json:"bodySize"`
+	TransferSize int64           `json:"_transferSize,omitempty"`
+}
+
+// HARNameValue is a name-value pair used for headers, query strings and form parameters.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARCookie contains a cookie used in a request or response.
+type HARCookie struct {
+	Name     string     `json:"name"`
+	Value    string     `json:"value"`
+	Path     string     `json:"path,omitempty"`
+	Domain   string     `json:"domain,omitempty"`
+	Expires  *time.Time `json:"expires,omitempty"`
+	HTTPOnly bool       `json:"httpOnly,omitempty"`
+	Secure   bool       `json:"secure,omitempty"`
+}
+
+// HARPostData describes posted data.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent describes the response content.
+type HARContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Bytes returns the decoded content.
+func (c *HARContent) Bytes() ([]byte, error) {
+	if c.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(c.Text)
+	}
+	return []byte(c.Text), nil
+}
+
+// HARTimings describes the phases of a request in milliseconds; -1 means the phase does not apply.
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// HAROptions configures a HARRecorder.
+type HAROptions struct {
+	Content bool // Record response bodies
+	Base64  bool // Encode all response bodies as base64, otherwise only binary bodies are encoded
+}
+
+// HARRecorder records network traffic as HAR entries.
+type HARRecorder struct {
+	opts HAROptions
+
+	mu      sync.Mutex
+	entries []*HAREntry
+	stopped bool
+
+	wg   sync.WaitGroup // pending body fetches
+	stop context.CancelFunc
+}
+
+// harPending holds an in-flight request until it finishes.
+type harPending struct {
+	request  *network.EventRequestWillBeSent
+	response *network.Response
+}
+
+// harFetchTimeout bounds fetching a request or response body for an entry.
+const harFetchTimeout = 10 * time.Second
+
+// NewHARRecorder records network events matching the URL pattern from ctx until ctx is done or Stop is called.
+// A nil opts records entries without response bodies.
+func NewHARRecorder(ctx context.Context, url any, opts *HAROptions) *HARRecorder {
+	r := new(HARRecorder)
+	if opts != nil {
+		r.opts = *opts
+	}
+	lctx, stop := context.WithCancel(ctx)
+	r.stop = stop
+	// finish fetches the bodies of a request in the background and adds its entry.
+	finish := func(p *harPending, end *network.EventLoadingFinished, endTime *cdp.MonotonicTime, errorText string) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.stopped {
+			return
+		}
+		r.wg.Go(func() {
+			ctx, cancel := context.WithTimeout(ctx, harFetchTimeout)
+			defer cancel()
+			var data, body []byte
+			if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) (err error) {
+				// Large request bodies are not included in the event.
+				if req := p.request.Request; req.HasPostData && len(req.PostDataEntries) == 0 {
+					if data, err = network.GetRequestPostData(p.request.RequestID).Do(ctx); err != nil {
+						return
+					}
+				}
+				if r.opts.Content && end != nil && p.response != nil {
+					body, err = network.GetResponseBody(p.request.RequestID).Do(ctx)
+				}
+				return
+			})); err != nil {
+				slog.Debug(err.Error())
+			}
+			r.add(p, data, end, endTime, body, errorText)
+		})
+	}
+	var m sync.Map
+	chromedp.ListenTarget(lctx, func(v any) {
+		switch ev := v.(type) {
+		case *network.EventRequestWillBeSent:
+			if ev.RedirectResponse != nil {
+				if v, ok := m.LoadAndDelete(ev.RequestID); ok {
+					p := v.(*harPending)
+					p.response = ev.RedirectResponse
+					finish(p, nil, ev.Timestamp, "")
+				}
+			}
+			if match(ev.Request.URL, url) {
+				m.Store(ev.RequestID, &harPending{request: ev})
+			}
+		case *network.EventResponseReceived:
+			if v, ok := m.Load(ev.RequestID); ok {
+				v.(*harPending).response = ev.Response
+			}
+		case *network.EventLoadingFinished:
+			if v, ok := m.LoadAndDelete(ev.RequestID); ok {
+				finish(v.(*harPending), ev, ev.Timestamp, "")
+			}
+		case *network.EventLoadingFailed:
+			if v, ok := m.LoadAndDelete(ev.RequestID); ok {
+				finish(v.(*harPending), nil, ev.Timestamp, ev.ErrorText)
+			}
+		}
+	})
+	return r
+}
+
+// Stop stops recording and waits until the bodies of finished requests have been fetched.
+// Call it before WriteTo to make sure all entries are written.
+func (r *HARRecorder) Stop() {
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+	r.stop()
+	r.wg.Wait()
+}
+
+// add converts a finished request to a HAR entry.
+// data is the request body when it is not included in the request.
+func (r *HARRecorder) add(p *harPending, data []byte, end *network.EventLoadingFinished, endTime *cdp.MonotonicTime, body []byte, errorText string) {
+	req := p.request.Request
+	entry := &HAREntry{
+		Request:      harRequest(req, p.response, data),
+		Response:     harResponse(p.response, body, r.opts.Base64),
+		Timings:      &HARTimings{Blocked: -1, DNS: -1, Connect: -1, Send: 0, Wait: 0, Receive: 0, SSL: -1},
+		ResourceType: strings.ToLower(string(p.request.Type)),
+		Error:        errorText,
+	}
+	if p.request.WallTime != nil {
+		entry.StartedDateTime = time.Time(*p.request.WallTime)
+	}
+	if p.response != nil {
+		entry.ServerIPAddress = p.response.RemoteIPAddress
+		if p.response.Status >= 300 && p.response.Status < 400 {
+			entry.Response.RedirectURL = harHeader(p.response.Headers, "Location")
+		}
+	}
+	if end != nil {
+		entry.Response.TransferSize = int64(end.EncodedDataLength)
+	}
+	var total float64
+	if p.request.Timestamp != nil && endTime != nil {
+		total = float64(endTime.Time().Sub(p.request.Timestamp.Time())) / float64(time.Millisecond)
+	}
+	if p.response != nil && p.response.Timing != nil {
+		entry.Timings = harTimings(p.response.Timing, total)
+	} else {
+		entry.Timings.Wait = total
+	}
+	entry.Time = max(total, 0)
+
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.mu.Unlock()
+}
+
+// harTimings converts resource timing to HAR timings given the total duration in milliseconds.
+func harTimings(t *network.ResourceTiming, total float64) *HARTimings {
+	span := func(start, end float64) float64 {
+		if start < 0 || end < 0 {
+			return -1
+		}
+		return end - start
+	}
+	timings := &HARTimings{
+		DNS:     span(t.DNSStart, t.DNSEnd),
+		Connect: span(t.ConnectStart, t.ConnectEnd),
+		SSL:     span(t.SslStart, t.SslEnd),
+		Send:    max(span(t.SendStart, t.SendEnd), 0),
+		Wait:    max(span(t.SendEnd, t.ReceiveHeadersEnd), 0),
+	}
+	timings.Blocked = -1
+	for _, start := range []float64{t.DNSStart, t.ConnectStart, t.SendStart} {
+		if start >= 0 {
+			timings.Blocked = start
+			break
+		}
+	}
+	// total is measured from the request event, which precedes the timing baseline slightly.
+	timings.Receive = max(total-max(t.ReceiveHeadersEnd, 0), 0)
+	return timings
+}
+
+// harHeader returns the value of a header, matching the name case-insensitively.
+func harHeader(headers network.Headers, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// harHeaders converts protocol headers to HAR name-value pairs, splitting multi-value headers.
+func harHeaders(headers network.Headers) []*HARNameValue {
+	res := []*HARNameValue{}
+	for k, v := range headers {
+		for v := range strings.SplitSeq(fmt.Sprint(v), "\n") {
+			res = append(res, &HARNameValue{k, v})
+		}
+	}
+	slices.SortStableFunc(res, func(a, b *HARNameValue) int { return strings.Compare(a.Name, b.Name) })
+	return res
+}
+
+// harRequest converts a protocol request to a HAR request.
+// data is the request body if it was fetched separately.
+func harRequest(req *network.Request, resp *network.Response, data []byte) *HARRequest {
+	headers := req.Headers
+	version := "HTTP/1.1"
+	if resp != nil {
+		if len(resp.RequestHeaders) > 0 {
+			headers = resp.RequestHeaders
+		}
+		version = httpVersion(resp.Protocol)
+	}
+	res := &HARRequest{
+		Method:      req.Method,
+		URL:         req.URL,
+		HTTPVersion: version,
+		Cookies:     []*HARCookie{},
+		Headers:     harHeaders(headers),
+		QueryString: []*HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+	if u, err := url.Parse(req.URL); err == nil {
+		for k, vs := range u.Query() {
+			for _, v := range vs {
+				res.QueryString = append(res.QueryString, &HARNameValue{k, v})
+			}
+		}
+		slices.SortStableFunc(res.QueryString, func(a, b *HARNameValue) int { return strings.Compare(a.Name, b.Name) })
+	}
+	if cookie := harHeader(headers, "Cookie"); cookie != "" {
+		if cookies, err := http.ParseCookie(cookie); err == nil {
+			for _, i := range cookies {
+				res.Cookies = append(res.Cookies, &HARCookie{Name: i.Name, Value: i.Value})
+			}
+		}
+	}
+	if req.HasPostData {
+		if data == nil {
+			data = postData(req)
+		}
+		res.PostData = &HARPostData{MimeType: harHeader(headers, "Content-Type"), Text: string(data)}
+		res.BodySize = int64(len(data))
+	}
+	return res
+}
+
+// isTextMimeType reports whether a MIME type denotes textual content.
+func isTextMimeType(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "text/") ||
+		strings.Contains(mimeType, "json") ||
+		strings.Contains(mimeType, "javascript") ||
+		strings.Contains(mimeType, "xml") ||
+		mimeType == "image/svg+xml"
+}
+
+// harResponse converts a protocol response to a HAR response.
+func harResponse(resp *network.Response, body []byte, base64Body bool) *HARResponse {
+	res := &HARResponse{
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []*HARCookie{},
+		Headers:     []*HARNameValue{},
+		Content:     &HARContent{Size: int64(len(body)), MimeType: "x-unknown"},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	if resp == nil {
+		return res
+	}
+	res.Status = resp.Status
+	res.StatusText = resp.StatusText
+	if res.StatusText == "" {
+		res.StatusText = http.StatusText(int(resp.Status))
+	}
+	res.HTTPVersion = httpVersion(resp.Protocol)
+	res.Headers = harHeaders(resp.Headers)
+	for _, i := range res.Headers {
+		if strings.EqualFold(i.Name, "Set-Cookie") {
+			if c, err := http.ParseSetCookie(i.Value); err == nil {
+				cookie := &HARCookie{Name: c.Name, Value: c.Value, Path: c.Path, Domain: c.Domain, HTTPOnly: c.HttpOnly, Secure: c.Secure}
+				if !c.Expires.IsZero() {
+					cookie.Expires = &c.Expires
+				}
+				res.Cookies = append(res.Cookies, cookie)
+			}
+		}
+	}
+	if resp.MimeType != "" {
+		res.Content.MimeType = resp.MimeType
+	}
+	if body != nil {
+		res.BodySize = int64(len(body))
+		if base64Body || !isTextMimeType(resp.MimeType) {
+			res.Content.Text, res.Content.Encoding = base64.StdEncoding.EncodeToString(body), "base64"
+		} else {
+			res.Content.Text = string(body)
+		}
+	}
+	return res
+}
+
+// Entries returns the entries recorded so far, ordered by start time.
+func (r *HARRecorder) Entries() []*HAREntry {
+	r.mu.Lock()
+	entries := slices.Clone(r.entries)
+	r.mu.Unlock()
+	slices.SortStableFunc(entries, func(a, b *HAREntry) int { return a.StartedDateTime.Compare(b.StartedDateTime) })
+	return entries
+}
+
+// HAR returns a HAR document containing the entries recorded so far.
+func (r *HARRecorder) HAR() *HAR {
+	return &HAR{&HARLog{
+		Version: "1.2",
+		Creator: &HARCreator{Name: "github.com/sunshineplan/chrome", Version: "1.0"},
+		Entries: r.Entries(),
+	}}
+}
+
+// WriteTo writes the entries recorded so far as a HAR 1.2 file to w.
+// Entries whose bodies are still being fetched are not included until Stop returns.
+func (r *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.MarshalIndent(r.HAR(), "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
@@ -0,0 +1,98 @@
+package chrome
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestHARRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, "/api", http.StatusFound)
+		case "/api":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "1"})
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"ok":true}`)
+		case "/upload":
+			n, _ := io.Copy(io.Discard, r.Body)
+			fmt.Fprint(w, n)
+		default:
+			fmt.Fprint(w, `<script>
+fetch("/redirect", {method: "POST", body: "data"});
+fetch("/upload", {method: "POST", body: "x".repeat(1 << 20)});
+</script>`)
+		}
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	r := NewHARRecorder(ctx, ts.URL, &HAROptions{Content: true})
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	if err := WaitNetworkIdle(ctx, 500*time.Millisecond, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Stop()
+
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var har HAR
+	if err := json.Unmarshal(buf.Bytes(), &har); err != nil {
+		t.Fatal(err)
+	}
+	if har.Log.Version != "1.2" {
+		t.Errorf("expected version 1.2; got %s", har.Log.Version)
+	}
+
+	entries := make(map[string]*HAREntry)
+	for _, i := range har.Log.Entries {
+		entries[i.Request.URL] = i
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries; got %d", len(entries))
+	}
+	if e := entries[ts.URL+"/redirect"]; e == nil {
+		t.Error("expected redirect entry")
+	} else {
+		if e.Response.Status != http.StatusFound || e.Response.RedirectURL != "/api" {
+			t.Errorf("unexpected redirect response: %d %q", e.Response.Status, e.Response.RedirectURL)
+		}
+		if e.Request.PostData == nil || e.Request.PostData.Text != "data" {
+			t.Errorf("expected post data %q; got %v", "data", e.Request.PostData)
+		}
+	}
+	if e := entries[ts.URL+"/upload"]; e == nil {
+		t.Error("expected upload entry")
+	} else if e.Request.PostData == nil || len(e.Request.PostData.Text) != 1<<20 || e.Request.BodySize != 1<<20 {
+		t.Errorf("expected large post data; got body size %d", e.Request.BodySize)
+	}
+	if e := entries[ts.URL+"/api"]; e == nil {
+		t.Error("expected api entry")
+	} else {
+		if e.Response.Content.Text != `{"ok":true}` {
+			t.Errorf("expected content %q; got %q", `{"ok":true}`, e.Response.Content.Text)
+		}
+		if len(e.Response.Cookies) != 1 || e.Response.Cookies[0].Name != "session" {
+			t.Errorf("expected session cookie; got %v", e.Response.Cookies)
+		}
+	}
+}
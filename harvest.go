@@ -0,0 +1,176 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// harvestScrollScript scrolls the container or the document to its bottom and reports the scroll state.
+const harvestScrollScript = `((container, end) => {
+	const el = container ? document.querySelector(container) : document.scrollingElement;
+	if (!el) throw new Error("container not found: " + container);
+	el.scrollTop = el.scrollHeight;
+	return {
+		height: el.scrollHeight,
+		bottom: el.scrollTop + el.clientHeight >= el.scrollHeight - 1,
+		end: !!(end && document.querySelector(end)),
+	};
+})(%s, %s)`
+
+// HarvestOptions configures ScrollHarvest.
+// Zero values use the defaults noted on each field.
+type HarvestOptions struct {
+	Container   string        // CSS selector of the scrolling container, the page if empty
+	EndSelector string        // CSS selector of an element marking the end of the feed
+	MaxItems    int           // Stop after this many responses, zero for no limit
+	IdleTimeout time.Duration // Stop when no matching response arrives within this duration, default 5s
+	Interval    time.Duration // Delay between scrolls, default 500ms
+	EndChecks   int           // Consecutive scrolls at the bottom without growth that mark the end, default 3
+}
+
+// harvestState is the scroll state reported by harvestScrollScript.
+type harvestState struct {
+	Height float64 `json:"height"`
+	Bottom bool    `json:"bottom"`
+	End    bool    `json:"end"`
+}
+
+// ScrollHarvest repeatedly scrolls the page or a container to its bottom and delivers the network events
+// matching url, with response bodies, as ListenEvent does. The url argument accepts the same matchers.
+// It stops and closes the channel when MaxItems events have been delivered, when no matching response
+// arrives within IdleTimeout, when the end of the page is detected and the matching requests still
+// in flight have been delivered, or when ctx is done. A nil opts uses the default options.
+func ScrollHarvest(ctx context.Context, url any, opts *HarvestOptions) <-chan *Event {
+	var o HarvestOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.IdleTimeout <= 0 {
+		o.IdleTimeout = 5 * time.Second
+	}
+	if o.Interval <= 0 {
+		o.Interval = 500 * time.Millisecond
+	}
+	if o.EndChecks <= 0 {
+		o.EndChecks = 3
+	}
+	container, _ := json.Marshal(o.Container)
+	end, _ := json.Marshal(o.EndSelector)
+	script := fmt.Sprintf(harvestScrollScript, container, end)
+
+	ctx, cancel := context.WithCancel(ctx)
+	// Matching requests are in flight from when they are sent until they are delivered or fail.
+	var mu sync.Mutex
+	inflight := make(map[network.RequestID]struct{})
+	failed := make(chan struct{}, 1)
+	chromedp.ListenTarget(ctx, func(v any) {
+		switch ev := v.(type) {
+		case *network.EventRequestWillBeSent:
+			if match(ev.Request.URL, url) {
+				mu.Lock()
+				inflight[ev.RequestID] = struct{}{}
+				mu.Unlock()
+			}
+		case *network.EventLoadingFailed:
+			mu.Lock()
+			delete(inflight, ev.RequestID)
+			mu.Unlock()
+			select {
+			case failed <- struct{}{}:
+			default:
+			}
+		}
+	})
+	settled := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(inflight) == 0
+	}
+	events := ListenEvent(ctx, url, "", true)
+	c := make(chan *Event, DefaultChannelBufferCapacity)
+	go func() {
+		defer close(c)
+		defer cancel()
+
+		ticker := time.NewTicker(o.Interval)
+		defer ticker.Stop()
+		idle := time.NewTimer(o.IdleTimeout)
+		defer idle.Stop()
+
+		var count, still int
+		var height float64
+		scroll := func() bool {
+			var state harvestState
+			if err := chromedp.Run(ctx, chromedp.Evaluate(script, &state)); err != nil {
+				slog.Debug(err.Error())
+				return false
+			}
+			if state.End {
+				return false
+			}
+			if state.Bottom && state.Height == height {
+				still++
+			} else {
+				still = 0
+			}
+			height = state.Height
+			return still < o.EndChecks
+		}
+		// Once the end is detected, scrolling stops and the requests in flight are still delivered.
+		ended := !scroll()
+		if ended && settled() {
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-idle.C:
+				return
+			case <-failed:
+				if ended && settled() {
+					return
+				}
+			case e, ok := <-events:
+				if !ok {
+					return
+				}
+				mu.Lock()
+				delete(inflight, e.Request.RequestID)
+				mu.Unlock()
+				select {
+				case c <- e:
+				case <-ctx.Done():
+					return
+				}
+				if count++; o.MaxItems > 0 && count >= o.MaxItems {
+					return
+				}
+				if ended && settled() {
+					return
+				}
+				still = 0
+				idle.Reset(o.IdleTimeout)
+			case <-ticker.C:
+				if !ended && !scroll() {
+					if ended = true; settled() {
+						return
+					}
+				}
+			}
+		}
+	}()
+	return c
+}
+
+// ScrollHarvest scrolls the page of this Chrome instance and delivers the matching network events.
+func (c *Chrome) ScrollHarvest(url any, opts *HarvestOptions) <-chan *Event {
+	return ScrollHarvest(c, url, opts)
+}
@@ -0,0 +1,84 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// testFeed loads its first page on the first scroll, so that ScrollHarvest sees every response.
+const testFeed = `<!DOCTYPE html><html><body><div style="height:2000px"></div><div id="feed"></div><script>
+let page = 0, loading = false;
+async function more() {
+	if (loading) return;
+	loading = true;
+	const res = await fetch("/api?page=" + page++);
+	const items = await res.json();
+	for (const i of items) {
+		const div = document.createElement("div");
+		div.style.height = "500px";
+		div.textContent = i;
+		document.getElementById("feed").append(div);
+	}
+	loading = false;
+}
+addEventListener("scroll", () => {
+	if (innerHeight + scrollY >= document.body.scrollHeight - 10) more();
+});
+</script></body></html>`
+
+func TestScrollHarvest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api" {
+			fmt.Fprint(w, testFeed)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Header().Set("Content-Type", "application/json")
+		if page >= 3 {
+			fmt.Fprint(w, "[]")
+			return
+		}
+		fmt.Fprintf(w, `["item %d-1","item %d-2","item %d-3"]`, page, page, page)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 20*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	var items []string
+	for e := range ScrollHarvest(ctx, ts.URL+"/api", &HarvestOptions{IdleTimeout: 2 * time.Second, Interval: 200 * time.Millisecond}) {
+		var res []string
+		if err := e.Unmarshal(&res); err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, res...)
+	}
+	if len(items) != 9 {
+		t.Errorf("expected 9 items; got %d: %v", len(items), items)
+	}
+
+	if err := chromedp.Run(ctx, chromedp.Reload()); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	for range ScrollHarvest(ctx, ts.URL+"/api", &HarvestOptions{MaxItems: 2, Interval: 200 * time.Millisecond}) {
+		n++
+	}
+	if n != 2 {
+		t.Errorf("expected 2 responses; got %d", n)
+	}
+}
@@ -0,0 +1,76 @@
+package chrome
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// WaitNetworkIdle waits until the number of in-flight requests stays at or below maxInflight for the idle duration.
+// Requests are tracked from EventRequestWillBeSent until they finish, fail or are cancelled.
+// Requests whose URL matches any of the ignore patterns, such as analytics beacons or long-polling connections, are not counted.
+// Requests started before the call are not tracked.
+func WaitNetworkIdle(ctx context.Context, idle time.Duration, maxInflight int, ignore ...any) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	inflight := make(map[network.RequestID]struct{})
+	changed := make(chan struct{}, 1)
+	chromedp.ListenTarget(ctx, func(v any) {
+		mu.Lock()
+		switch ev := v.(type) {
+		case *network.EventRequestWillBeSent:
+			for _, i := range ignore {
+				if i != nil && i != "" && match(ev.Request.URL, i) {
+					mu.Unlock()
+					return
+				}
+			}
+			inflight[ev.RequestID] = struct{}{}
+		case *network.EventLoadingFinished:
+			delete(inflight, ev.RequestID)
+		case *network.EventLoadingFailed:
+			delete(inflight, ev.RequestID)
+		default:
+			mu.Unlock()
+			return
+		}
+		mu.Unlock()
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	if err := chromedp.Run(ctx); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case <-changed:
+			mu.Lock()
+			n := len(inflight)
+			mu.Unlock()
+			if n > maxInflight {
+				timer.Stop()
+			} else {
+				timer.Reset(idle)
+			}
+		}
+	}
+}
+
+// WaitNetworkIdle waits until the network of this Chrome instance is idle.
+func (c *Chrome) WaitNetworkIdle(idle time.Duration, maxInflight int, ignore ...any) error {
+	return WaitNetworkIdle(c, idle, maxInflight, ignore...)
+}
@@ -0,0 +1,50 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestWaitNetworkIdle(t *testing.T) {
+	var finished, polled atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(time.Second)
+			finished.Store(true)
+			fmt.Fprint(w, "slow")
+		case "/poll":
+			polled.Store(true)
+			<-r.Context().Done()
+		default:
+			fmt.Fprint(w, `<script>setTimeout(() => { fetch("/slow"); fetch("/poll") }, 100)</script>`)
+		}
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	if err := WaitNetworkIdle(ctx, 500*time.Millisecond, 0, URLHasSuffix("/poll")); err != nil {
+		t.Fatal(err)
+	}
+	if !finished.Load() {
+		t.Error("expected slow request finished before network idle")
+	}
+	if !polled.Load() {
+		t.Error("expected long-polling request to be sent")
+	}
+}
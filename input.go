@@ -0,0 +1,289 @@
+package chrome
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/dom"
+	"github.com/chromedp/cdproto/input"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// ErrElementNotVisible is returned when an element has no visible box to interact with.
+var ErrElementNotVisible = errors.New("element not visible")
+
+// InputOptions configures human-like input simulation.
+// Zero values use the defaults noted on each field.
+type InputOptions struct {
+	Seed         uint64        // Seed of the random source; runs with the same seed are reproducible
+	MoveDuration time.Duration // Duration of a mouse movement, default 400ms
+	MoveSteps    int           // Number of mouse move events per movement, default 25
+	KeyDelay     time.Duration // Delay between key strokes, default 100ms
+	KeyJitter    time.Duration // Maximum random deviation of the key delay, default 50ms, negative for none
+	ScrollStep   float64       // Average wheel delta per scroll event in pixels, default 100
+}
+
+// Input simulates human-like mouse, keyboard and wheel input in a page.
+// The mouse position is tracked across calls, starting at the top left corner.
+type Input struct {
+	ctx  context.Context
+	opts InputOptions
+
+	mu   sync.Mutex
+	rand *rand.Rand
+	x, y float64
+}
+
+// NewInput returns an Input dispatching events to the page of ctx.
+// A nil opts uses the default options.
+func NewInput(ctx context.Context, opts *InputOptions) *Input {
+	in := &Input{ctx: ctx}
+	if opts != nil {
+		in.opts = *opts
+	}
+	if in.opts.MoveDuration <= 0 {
+		in.opts.MoveDuration = 400 * time.Millisecond
+	}
+	if in.opts.MoveSteps <= 0 {
+		in.opts.MoveSteps = 25
+	}
+	if in.opts.KeyDelay <= 0 {
+		in.opts.KeyDelay = 100 * time.Millisecond
+	}
+	if in.opts.KeyJitter == 0 {
+		in.opts.KeyJitter = 50 * time.Millisecond
+	} else if in.opts.KeyJitter < 0 {
+		in.opts.KeyJitter = 0
+	}
+	if in.opts.ScrollStep <= 0 {
+		in.opts.ScrollStep = 100
+	}
+	in.rand = rand.New(rand.NewPCG(in.opts.Seed, in.opts.Seed^0x9e3779b97f4a7c15))
+	return in
+}
+
+// sleep pauses for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// between returns a random value in [lo, hi).
+func (in *Input) between(lo, hi float64) float64 {
+	return lo + in.rand.Float64()*(hi-lo)
+}
+
+// jitter returns d deviated randomly by up to j in either direction.
+func (in *Input) jitter(d, j time.Duration) time.Duration {
+	return max(d+time.Duration(in.between(-float64(j), float64(j))), 0)
+}
+
+// easeInOut is a cubic ease-in-out curve on [0, 1].
+func easeInOut(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// bezier returns the point at t on the cubic Bézier curve p0, p1, p2, p3.
+func bezier(t float64, p0, p1, p2, p3 [2]float64) (x, y float64) {
+	u := 1 - t
+	a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+	return a*p0[0] + b*p1[0] + c*p2[0] + d*p3[0], a*p0[1] + b*p1[1] + c*p2[1] + d*p3[1]
+}
+
+// move moves the mouse to (x, y) along an eased curve with random control points.
+func (in *Input) move(ctx context.Context, x, y float64) error {
+	p0, p3 := [2]float64{in.x, in.y}, [2]float64{x, y}
+	dx, dy := x-in.x, y-in.y
+	dist := math.Hypot(dx, dy)
+	// Control points deviate perpendicular to the straight line by up to a quarter of its length.
+	nx, ny := 0.0, 0.0
+	if dist > 0 {
+		nx, ny = -dy/dist, dx/dist
+	}
+	off1, off2 := in.between(-dist/4, dist/4), in.between(-dist/4, dist/4)
+	p1 := [2]float64{in.x + dx*in.between(0.2, 0.4) + nx*off1, in.y + dy*in.between(0.2, 0.4) + ny*off1}
+	p2 := [2]float64{in.x + dx*in.between(0.6, 0.8) + nx*off2, in.y + dy*in.between(0.6, 0.8) + ny*off2}
+
+	step := in.opts.MoveDuration / time.Duration(in.opts.MoveSteps)
+	for i := 1; i <= in.opts.MoveSteps; i++ {
+		px, py := bezier(easeInOut(float64(i)/float64(in.opts.MoveSteps)), p0, p1, p2, p3)
+		if err := input.DispatchMouseEvent(input.MouseMoved, px, py).Do(ctx); err != nil {
+			return err
+		}
+		in.x, in.y = px, py
+		if err := sleep(ctx, in.jitter(step, step/2)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// box returns the viewport box of the first element matching selector.
+func (in *Input) box(ctx context.Context, selector string) (x, y, w, h float64, err error) {
+	var nodes []*cdp.Node
+	if err = chromedp.Nodes(selector, &nodes, chromedp.ByQuery, FrameQuery(in.ctx)).Do(ctx); err != nil {
+		return
+	}
+	quads, err := dom.GetContentQuads().WithNodeID(nodes[0].NodeID).Do(ctx)
+	if err != nil {
+		return
+	}
+	if len(quads) == 0 || len(quads[0]) < 8 {
+		err = ErrElementNotVisible
+		return
+	}
+	q := quads[0]
+	minX, maxX, minY, maxY := q[0], q[0], q[1], q[1]
+	for i := 2; i < 8; i += 2 {
+		minX, maxX = min(minX, q[i]), max(maxX, q[i])
+		minY, maxY = min(minY, q[i+1]), max(maxY, q[i+1])
+	}
+	return minX, minY, maxX - minX, maxY - minY, nil
+}
+
+// scroll dispatches wheel events at the mouse position until dx and dy have been scrolled.
+func (in *Input) scroll(ctx context.Context, dx, dy float64) error {
+	for dx != 0 || dy != 0 {
+		step := in.opts.ScrollStep * in.between(0.7, 1.3)
+		sx, sy := math.Copysign(min(math.Abs(dx), step), dx), math.Copysign(min(math.Abs(dy), step), dy)
+		if err := input.DispatchMouseEvent(input.MouseWheel, in.x, in.y).WithDeltaX(sx).WithDeltaY(sy).Do(ctx); err != nil {
+			return err
+		}
+		dx, dy = dx-sx, dy-sy
+		if err := sleep(ctx, in.jitter(40*time.Millisecond, 20*time.Millisecond)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reveal scrolls an element into the viewport with wheel events and returns its box.
+func (in *Input) reveal(ctx context.Context, selector string) (x, y, w, h float64, err error) {
+	if x, y, w, h, err = in.box(ctx, selector); err != nil {
+		return
+	}
+	_, _, _, _, viewport, _, err := page.GetLayoutMetrics().Do(ctx)
+	if err != nil {
+		return
+	}
+	vw, vh := viewport.ClientWidth, viewport.ClientHeight
+	var dx, dy float64
+	if x < 0 || x+w > vw {
+		dx = math.Round(x + w/2 - vw/2)
+	}
+	if y < 0 || y+h > vh {
+		dy = math.Round(y + h/2 - vh/2)
+	}
+	if dx == 0 && dy == 0 {
+		return
+	}
+	if err = in.scroll(ctx, dx, dy); err != nil {
+		return
+	}
+	// Let the page settle before measuring the box again.
+	if err = sleep(ctx, 100*time.Millisecond); err != nil {
+		return
+	}
+	return in.box(ctx, selector)
+}
+
+// hover moves the mouse to a random point near the center of an element.
+func (in *Input) hover(ctx context.Context, selector string) error {
+	x, y, w, h, err := in.reveal(ctx, selector)
+	if err != nil {
+		return err
+	}
+	return in.move(ctx, x+w*in.between(0.3, 0.7), y+h*in.between(0.3, 0.7))
+}
+
+// run runs fn with the input state locked.
+func (in *Input) run(fn func(context.Context) error) error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return chromedp.Run(in.ctx, chromedp.ActionFunc(fn))
+}
+
+// MoveTo moves the mouse to the viewport point (x, y) along an eased curve.
+func (in *Input) MoveTo(x, y float64) error {
+	return in.run(func(ctx context.Context) error { return in.move(ctx, x, y) })
+}
+
+// Hover scrolls the element selected by selector into view if needed and moves the mouse over it.
+func (in *Input) Hover(selector string) error {
+	return in.run(func(ctx context.Context) error { return in.hover(ctx, selector) })
+}
+
+// Click hovers the element selected by selector and clicks it with the left button.
+func (in *Input) Click(selector string) error {
+	return in.run(func(ctx context.Context) error {
+		if err := in.hover(ctx, selector); err != nil {
+			return err
+		}
+		if err := input.DispatchMouseEvent(input.MousePressed, in.x, in.y).
+			WithButton(input.Left).WithButtons(1).WithClickCount(1).Do(ctx); err != nil {
+			return err
+		}
+		if err := sleep(ctx, in.jitter(80*time.Millisecond, 40*time.Millisecond)); err != nil {
+			return err
+		}
+		return input.DispatchMouseEvent(input.MouseReleased, in.x, in.y).
+			WithButton(input.Left).WithClickCount(1).Do(ctx)
+	})
+}
+
+// Type types text into the focused element one key at a time,
+// waiting the key delay with random jitter after each key.
+func (in *Input) Type(text string) error {
+	return in.run(func(ctx context.Context) error {
+		for _, r := range text {
+			if err := chromedp.KeyEvent(string(r)).Do(ctx); err != nil {
+				return err
+			}
+			if err := sleep(ctx, in.jitter(in.opts.KeyDelay, in.opts.KeyJitter)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// TypeInto clicks the element selected by selector to focus it and types text into it.
+func (in *Input) TypeInto(selector, text string) error {
+	if err := in.Click(selector); err != nil {
+		return err
+	}
+	return in.Type(text)
+}
+
+// Scroll scrolls by (dx, dy) pixels with wheel events at the mouse position.
+func (in *Input) Scroll(dx, dy float64) error {
+	return in.run(func(ctx context.Context) error { return in.scroll(ctx, dx, dy) })
+}
+
+// ScrollTo scrolls the element selected by selector into view with wheel events.
+func (in *Input) ScrollTo(selector string) error {
+	return in.run(func(ctx context.Context) error {
+		_, _, _, _, err := in.reveal(ctx, selector)
+		return err
+	})
+}
+
+// Input returns an Input dispatching events to the page of this Chrome instance.
+func (c *Chrome) Input(opts *InputOptions) *Input {
+	return NewInput(c, opts)
+}
@@ -0,0 +1,89 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestInputSeed(t *testing.T) {
+	a, b := NewInput(context.Background(), &InputOptions{Seed: 1}), NewInput(context.Background(), &InputOptions{Seed: 1})
+	for range 10 {
+		if x, y := a.jitter(time.Second, time.Second), b.jitter(time.Second, time.Second); x != y {
+			t.Fatalf("expected same sequence; got %v and %v", x, y)
+		}
+	}
+	if in := NewInput(context.Background(), nil); in.opts.KeyJitter != 50*time.Millisecond {
+		t.Errorf("expected default key jitter; got %v", in.opts.KeyJitter)
+	}
+	if in := NewInput(context.Background(), &InputOptions{KeyJitter: -1}); in.jitter(in.opts.KeyDelay, in.opts.KeyJitter) != in.opts.KeyDelay {
+		t.Error("expected no key jitter")
+	}
+	if v := easeInOut(0); v != 0 {
+		t.Errorf("expected 0; got %v", v)
+	}
+	if v := easeInOut(1); v != 1 {
+		t.Errorf("expected 1; got %v", v)
+	}
+	x, y := bezier(1, [2]float64{0, 0}, [2]float64{3, 7}, [2]float64{5, -2}, [2]float64{10, 20})
+	if math.Abs(x-10) > 1e-9 || math.Abs(y-20) > 1e-9 {
+		t.Errorf("expected end point; got %v, %v", x, y)
+	}
+}
+
+func TestInput(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<!DOCTYPE html><html><body style="height:3000px">
+<div id="hover" style="width:100px;height:50px" onmouseenter="this.textContent='hovered'"></div>
+<input id="input" style="margin-top:2000px" onclick="this.dataset.clicked=1">
+</body></html>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 20*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	in := NewInput(ctx, &InputOptions{Seed: 42, MoveDuration: 100 * time.Millisecond, KeyDelay: 10 * time.Millisecond, KeyJitter: 5 * time.Millisecond})
+	if err := in.Hover("#hover"); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.TypeInto("#input", "hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	var hover, value, clicked string
+	var scrollY float64
+	if err := chromedp.Run(ctx,
+		chromedp.Text("#hover", &hover, chromedp.ByQuery),
+		chromedp.Value("#input", &value, chromedp.ByQuery),
+		chromedp.Evaluate(`document.querySelector("#input").dataset.clicked`, &clicked),
+		chromedp.Evaluate(`window.scrollY`, &scrollY),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "hovered"; hover != expect {
+		t.Errorf("expected %q; got %q", expect, hover)
+	}
+	if expect := "hello"; value != expect {
+		t.Errorf("expected %q; got %q", expect, value)
+	}
+	if expect := "1"; clicked != expect {
+		t.Errorf("expected %q; got %q", expect, clicked)
+	}
+	if scrollY == 0 {
+		t.Error("expected page to be scrolled")
+	}
+}
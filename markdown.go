@@ -0,0 +1,346 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// serializeScript defines abs, resolving URLs against the document, and serialize,
+// converting a DOM node into the JSON form of HTMLNode with absolute href and src attributes.
+const serializeScript = `
+	const abs = u => {
+		try {
+			return u ? new URL(u, document.baseURI).href : "";
+		} catch {
+			return "";
+		}
+	};
+	const serialize = node => {
+		if (node.nodeType === Node.TEXT_NODE) return { text: node.textContent };
+		if (node.nodeType !== Node.ELEMENT_NODE) return null;
+		const attrs = {};
+		for (const name of ["href", "src", "alt", "title", "class", "start"]) {
+			const v = node.getAttribute(name);
+			if (v !== null) attrs[name] = name === "href" || name === "src" ? abs(v) : v;
+		}
+		return { tag: node.tagName.toLowerCase(), attrs, children: Array.from(node.childNodes, serialize).filter(Boolean) };
+	};`
+
+// HTMLNode is a serialized DOM node. Text nodes have an empty Tag.
+type HTMLNode struct {
+	Tag      string            `json:"tag,omitempty"`      // Lowercase tag name of an element
+	Attrs    map[string]string `json:"attrs,omitempty"`    // Attributes relevant to conversion, with absolute URLs
+	Text     string            `json:"text,omitempty"`     // Content of a text node
+	Children []*HTMLNode       `json:"children,omitempty"` // Child nodes of an element
+}
+
+var (
+	mdSpaces   = regexp.MustCompile(`\s+`)
+	mdNewlines = regexp.MustCompile(`\n{3,}`)
+	mdEscaper  = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+	mdURL      = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29")
+)
+
+// mdBlocks are elements rendered as separate blocks.
+var mdBlocks = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "main": true, "header": true, "footer": true,
+	"figure": true, "figcaption": true, "address": true, "dl": true, "dd": true, "dt": true, "li": true,
+	"details": true, "summary": true, "aside": true, "nav": true, "form": true, "fieldset": true, "center": true,
+}
+
+// textContent returns the concatenated text of a node and its descendants.
+func (n *HTMLNode) textContent() string {
+	if n.Tag == "" {
+		return n.Text
+	}
+	var b strings.Builder
+	for _, c := range n.Children {
+		b.WriteString(c.textContent())
+	}
+	return b.String()
+}
+
+// mdNormalize trims trailing spaces of lines and collapses blank lines.
+func mdNormalize(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(mdNewlines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// mdChildren renders the children of a node.
+func mdChildren(n *HTMLNode) string {
+	var b strings.Builder
+	for _, c := range n.Children {
+		b.WriteString(mdNode(c))
+	}
+	return b.String()
+}
+
+// mdWrap wraps the rendered children in an inline marker, keeping surrounding spaces outside of it.
+func mdWrap(n *HTMLNode, marker string) string {
+	s := mdChildren(n)
+	inner := strings.TrimSpace(s)
+	if inner == "" {
+		return s
+	}
+	lead, trail := s[:strings.Index(s, inner)], s[strings.Index(s, inner)+len(inner):]
+	return lead + marker + inner + marker + trail
+}
+
+// mdFence returns a run of c long enough not to occur in s.
+func mdFence(s string, c string, n int) string {
+	fence := strings.Repeat(c, n)
+	for strings.Contains(s, fence) {
+		fence += c
+	}
+	return fence
+}
+
+// mdLanguage returns the code language declared by a language-* or lang-* class.
+func mdLanguage(n *HTMLNode) string {
+	for _, class := range strings.Fields(n.Attrs["class"]) {
+		if lang, ok := strings.CutPrefix(class, "language-"); ok {
+			return lang
+		}
+		if lang, ok := strings.CutPrefix(class, "lang-"); ok {
+			return lang
+		}
+	}
+	for _, c := range n.Children {
+		if c.Tag == "code" {
+			return mdLanguage(c)
+		}
+	}
+	return ""
+}
+
+// mdList renders an ordered or unordered list.
+func mdList(n *HTMLNode) string {
+	var b strings.Builder
+	i := 1
+	if start, err := strconv.Atoi(n.Attrs["start"]); err == nil {
+		i = start
+	}
+	for _, c := range n.Children {
+		if c.Tag != "li" {
+			continue
+		}
+		marker := "- "
+		if n.Tag == "ol" {
+			marker = strconv.Itoa(i) + ". "
+			i++
+		}
+		content := mdNormalize(mdChildren(c))
+		if !strings.Contains(content, "```") {
+			content = strings.ReplaceAll(content, "\n\n", "\n")
+		}
+		indent := strings.Repeat(" ", len(marker))
+		for j, line := range strings.Split(content, "\n") {
+			switch {
+			case j == 0:
+				b.WriteString(marker + line)
+			case line == "":
+			default:
+				b.WriteString(indent + line)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return "\n\n" + b.String() + "\n\n"
+}
+
+// mdTableRows collects the cells of the rows of a table, skipping nested tables.
+func mdTableRows(n *HTMLNode, rows *[][]string) {
+	for _, c := range n.Children {
+		switch c.Tag {
+		case "tr":
+			var row []string
+			for _, cell := range c.Children {
+				if cell.Tag == "th" || cell.Tag == "td" {
+					text := mdSpaces.ReplaceAllString(mdNormalize(mdChildren(cell)), " ")
+					row = append(row, strings.ReplaceAll(text, "|", `\|`))
+				}
+			}
+			*rows = append(*rows, row)
+		case "thead", "tbody", "tfoot":
+			mdTableRows(c, rows)
+		}
+	}
+}
+
+// mdTable renders a table as a GitHub Flavored Markdown table using its first row as the header.
+func mdTable(n *HTMLNode) string {
+	var rows [][]string
+	mdTableRows(n, &rows)
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\n")
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+		}
+	}
+	b.WriteString("\n\n")
+	return b.String()
+}
+
+// mdNode renders a node as Markdown.
+func mdNode(n *HTMLNode) string {
+	if n.Tag == "" {
+		return mdEscaper.Replace(mdSpaces.ReplaceAllString(n.Text, " "))
+	}
+	switch n.Tag {
+	case "script", "style", "noscript", "template", "head", "button", "input", "select", "textarea", "svg":
+		return ""
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		inner := mdSpaces.ReplaceAllString(strings.TrimSpace(mdChildren(n)), " ")
+		if inner == "" {
+			return ""
+		}
+		return "\n\n" + strings.Repeat("#", int(n.Tag[1]-'0')) + " " + inner + "\n\n"
+	case "br":
+		return "\\\n"
+	case "hr":
+		return "\n\n---\n\n"
+	case "strong", "b":
+		return mdWrap(n, "**")
+	case "em", "i":
+		return mdWrap(n, "*")
+	case "del", "s", "strike":
+		return mdWrap(n, "~~")
+	case "code", "kbd", "samp":
+		text := n.textContent()
+		if text == "" {
+			return ""
+		}
+		fence := mdFence(text, "`", 1)
+		if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+			text = " " + text + " "
+		}
+		return fence + text + fence
+	case "a":
+		s := mdChildren(n)
+		inner := strings.TrimSpace(s)
+		href := n.Attrs["href"]
+		if inner == "" || href == "" || strings.HasPrefix(href, "javascript:") {
+			return s
+		}
+		return "[" + inner + "](" + mdURL.Replace(href) + ")"
+	case "img":
+		if n.Attrs["src"] == "" {
+			return ""
+		}
+		return "![" + mdEscaper.Replace(n.Attrs["alt"]) + "](" + mdURL.Replace(n.Attrs["src"]) + ")"
+	case "pre":
+		text := strings.TrimRight(n.textContent(), "\n")
+		fence := mdFence(text, "`", 3)
+		return "\n\n" + fence + mdLanguage(n) + "\n" + text + "\n" + fence + "\n\n"
+	case "ul", "ol":
+		return mdList(n)
+	case "blockquote":
+		lines := strings.Split(mdNormalize(mdChildren(n)), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return "\n\n" + strings.Join(lines, "\n") + "\n\n"
+	case "table":
+		return mdTable(n)
+	}
+	if mdBlocks[n.Tag] {
+		return "\n\n" + strings.TrimSpace(mdChildren(n)) + "\n\n"
+	}
+	return mdChildren(n)
+}
+
+// Markdown converts a serialized DOM node to Markdown. It handles headings, paragraphs, emphasis,
+// links, images, ordered and unordered lists, blockquotes, code blocks and tables.
+func Markdown(n *HTMLNode) string {
+	if n == nil {
+		return ""
+	}
+	return mdNormalize(mdNode(n))
+}
+
+// PlainText converts a serialized DOM node to plain text, separating blocks by blank lines.
+func PlainText(n *HTMLNode) string {
+	var text func(*HTMLNode) string
+	text = func(n *HTMLNode) string {
+		if n.Tag == "" {
+			return mdSpaces.ReplaceAllString(n.Text, " ")
+		}
+		var b strings.Builder
+		for _, c := range n.Children {
+			b.WriteString(text(c))
+		}
+		switch {
+		case n.Tag == "script" || n.Tag == "style" || n.Tag == "noscript" || n.Tag == "template":
+			return ""
+		case n.Tag == "br":
+			return "\n"
+		case n.Tag == "pre":
+			return "\n\n" + n.textContent() + "\n\n"
+		case n.Tag == "td" || n.Tag == "th":
+			return b.String() + "\t"
+		case mdBlocks[n.Tag] || n.Tag == "tr" || n.Tag == "blockquote" || n.Tag == "ul" || n.Tag == "ol" ||
+			n.Tag == "table" || (len(n.Tag) == 2 && n.Tag[0] == 'h' && n.Tag[1] >= '1' && n.Tag[1] <= '6'):
+			return "\n\n" + strings.TrimSpace(b.String()) + "\n\n"
+		}
+		return b.String()
+	}
+	if n == nil {
+		return ""
+	}
+	return mdNormalize(text(n))
+}
+
+// SerializeNode serializes the first element matching selector in the page.
+func SerializeNode(ctx context.Context, selector string) (*HTMLNode, error) {
+	b, err := json.Marshal(selector)
+	if err != nil {
+		return nil, err
+	}
+	var node *HTMLNode
+	if err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`(selector => {%s
+	const el = document.querySelector(selector);
+	return el ? serialize(el) : null;
+})(%s)`, serializeScript, b), &node)); err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, fmt.Errorf("%w: %s", ErrElementNotFound, selector)
+	}
+	return node, nil
+}
+
+// ToMarkdown converts the first element matching selector in the rendered page to Markdown,
+// with links and images resolved to absolute URLs.
+func ToMarkdown(ctx context.Context, selector string) (string, error) {
+	node, err := SerializeNode(ctx, selector)
+	if err != nil {
+		return "", err
+	}
+	return Markdown(node), nil
+}
+
+// ToMarkdown converts an element of the page of this Chrome instance to Markdown.
+func (c *Chrome) ToMarkdown(selector string) (string, error) {
+	return ToMarkdown(c, selector)
+}
@@ -0,0 +1,38 @@
+package chrome
+
+import "testing"
+
+func htmlText(s string) *HTMLNode { return &HTMLNode{Text: s} }
+
+func htmlElem(tag string, attrs map[string]string, children ...*HTMLNode) *HTMLNode {
+	return &HTMLNode{Tag: tag, Attrs: attrs, Children: children}
+}
+
+func TestMarkdown(t *testing.T) {
+	n := htmlElem("div", nil,
+		htmlElem("h1", nil, htmlText("Title")),
+		htmlElem("p", nil, htmlText("Some "), htmlElem("strong", nil, htmlText("bold ")), htmlText("and a "),
+			htmlElem("a", map[string]string{"href": "https://example.com/a b"}, htmlText("link")), htmlText(".")),
+		htmlElem("ul", nil, htmlElem("li", nil, htmlText("one")), htmlElem("li", nil, htmlText("two"))),
+		htmlElem("ol", map[string]string{"start": "3"}, htmlElem("li", nil, htmlText("three"))),
+		htmlElem("pre", nil, htmlElem("code", map[string]string{"class": "language-go"}, htmlText("fmt.Println(1)\n"))),
+		htmlElem("table", nil,
+			htmlElem("tr", nil, htmlElem("th", nil, htmlText("A")), htmlElem("th", nil, htmlText("B"))),
+			htmlElem("tr", nil, htmlElem("td", nil, htmlText("1")), htmlElem("td", nil, htmlText("x|y")))),
+		htmlElem("img", map[string]string{"src": "https://example.com/img.png", "alt": "image"}),
+	)
+	expect := "# Title\n\n" +
+		"Some **bold** and a [link](https://example.com/a%20b).\n\n" +
+		"- one\n- two\n\n" +
+		"3. three\n\n" +
+		"```go\nfmt.Println(1)\n```\n\n" +
+		"| A | B |\n| --- | --- |\n| 1 | x\\|y |\n\n" +
+		"![image](https://example.com/img.png)"
+	if res := Markdown(n); res != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, res)
+	}
+
+	if expect, res := "Title\n\nSome bold and a link.", PlainText(htmlElem("div", nil, n.Children[0], n.Children[1])); res != expect {
+		t.Errorf("expected %q; got %q", expect, res)
+	}
+}
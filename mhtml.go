@@ -0,0 +1,94 @@
+package chrome
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// MHTMLOptions configures an MHTML capture.
+type MHTMLOptions struct {
+	NetworkIdle time.Duration // Wait until the network has been idle for this duration before capturing, zero to skip
+	LazyImages  bool          // Load lazy images and iframes by scrolling through the page before capturing
+}
+
+// loadLazyScript switches lazy images and iframes to eager loading and scrolls through the page
+// to trigger lazy loaders driven by scroll position, then scrolls back to the top.
+const loadLazyScript = `(async () => {
+	document.querySelectorAll('img[loading="lazy"], iframe[loading="lazy"]').forEach(e => e.loading = "eager");
+	for (let y = 0; y < document.documentElement.scrollHeight; y += window.innerHeight) {
+		window.scrollTo(0, y);
+		await new Promise(resolve => setTimeout(resolve, 100));
+	}
+	window.scrollTo(0, 0);
+	return true;
+})()`
+
+// loadLazy returns an action loading lazy resources of the page.
+func loadLazy() chromedp.Action {
+	return chromedp.Evaluate(loadLazyScript, nil, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	})
+}
+
+// SaveMHTML captures the page as a single-file MHTML archive and writes it to w.
+// A nil opts captures the page immediately.
+func SaveMHTML(ctx context.Context, w io.Writer, opts *MHTMLOptions) error {
+	if opts == nil {
+		opts = new(MHTMLOptions)
+	}
+	if opts.LazyImages {
+		if err := chromedp.Run(ctx, loadLazy(), waitResources()); err != nil {
+			return err
+		}
+	}
+	if opts.NetworkIdle > 0 {
+		if err := WaitNetworkIdle(ctx, opts.NetworkIdle, 0); err != nil {
+			return err
+		}
+	}
+	var data string
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) (err error) {
+		data, err = page.CaptureSnapshot().WithFormat(page.CaptureSnapshotFormatMhtml).Do(ctx)
+		return
+	})); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, data)
+	return err
+}
+
+// LoadMHTML opens a saved MHTML archive in the page.
+// The file must be readable by the browser process, so it is not supported on remote browsers.
+func LoadMHTML(ctx context.Context, name string) error {
+	path, err := filepath.Abs(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return chromedp.Run(ctx, chromedp.Navigate((&url.URL{Scheme: "file", Path: path}).String()))
+}
+
+// SaveMHTML captures the page of this Chrome instance as MHTML and writes it to w.
+func (c *Chrome) SaveMHTML(w io.Writer, opts *MHTMLOptions) error {
+	return SaveMHTML(c, w, opts)
+}
+
+// LoadMHTML opens a saved MHTML archive in this Chrome instance.
+func (c *Chrome) LoadMHTML(name string) error {
+	return LoadMHTML(c, name)
+}
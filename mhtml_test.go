@@ -0,0 +1,53 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestMHTML(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<p id="text">archived</p>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	name := filepath.Join(t.TempDir(), "page.mhtml")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveMHTML(ctx, f, &MHTMLOptions{NetworkIdle: 100 * time.Millisecond, LazyImages: true}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	ts.Close()
+
+	if err := LoadMHTML(ctx, name); err != nil {
+		t.Fatal(err)
+	}
+	var text string
+	if err := chromedp.Run(ctx, chromedp.Text("#text", &text)); err != nil {
+		t.Fatal(err)
+	}
+	if text != "archived" {
+		t.Errorf("expected %q; got %q", "archived", text)
+	}
+}
@@ -0,0 +1,260 @@
+package chrome
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/dom"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// WaitUntil specifies which page lifecycle event a navigation waits for.
+type WaitUntil int
+
+const (
+	// WaitUntilLoad waits for the load event.
+	WaitUntilLoad WaitUntil = iota
+	// WaitUntilDOMContentLoaded waits for the DOMContentLoaded event.
+	WaitUntilDOMContentLoaded
+	// WaitUntilNetworkIdle waits until there are no network connections for at least 500 ms.
+	WaitUntilNetworkIdle
+)
+
+// String returns the lifecycle event name reported by the browser.
+func (w WaitUntil) String() string {
+	switch w {
+	case WaitUntilLoad:
+		return "load"
+	case WaitUntilDOMContentLoaded:
+		return "DOMContentLoaded"
+	case WaitUntilNetworkIdle:
+		return "networkIdle"
+	default:
+		return fmt.Sprintf("WaitUntil(%d)", int(w))
+	}
+}
+
+// Page represents a single browser tab.
+// A Page is also a context.Context bound to its tab,
+// so it can be passed to package-level helpers such as ListenEvent, Download and Cookies.
+type Page struct {
+	context.Context
+
+	cancel context.CancelFunc
+	stop   func() bool
+}
+
+// newPage wraps a tab context into a Page which is closed when ctx is done.
+func newPage(ctx, tab context.Context, cancel context.CancelFunc) (*Page, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := chromedp.Run(tab, page.SetLifecycleEventsEnabled(true)); err != nil {
+		cancel()
+		return nil, err
+	}
+	return &Page{tab, cancel, context.AfterFunc(ctx, cancel)}, nil
+}
+
+// NewPage opens a new tab in the browser.
+// The page is closed when ctx is done or Close is called.
+func (c *Chrome) NewPage(ctx context.Context) (*Page, error) {
+	root, _, _, err := c.context(context.Background(), true)
+	if err != nil {
+		return nil, err
+	}
+	tab, cancel := chromedp.NewContext(root, c.ctxOpts...)
+	if err := chromedp.Run(tab, c.actions...); err != nil {
+		cancel()
+		return nil, err
+	}
+	return newPage(ctx, tab, cancel)
+}
+
+// NewPage opens a new tab in the session.
+// The page is closed when ctx is done, Close is called or the session is closed.
+func (s *Session) NewPage(ctx context.Context) (*Page, error) {
+	tab, cancel, err := s.NewContext()
+	if err != nil {
+		return nil, err
+	}
+	return newPage(ctx, tab, cancel)
+}
+
+// mainFrame returns the ID of the page's main frame.
+func mainFrame(ctx context.Context) (cdp.FrameID, error) {
+	tree, err := page.GetFrameTree().Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	return tree.Frame.ID, nil
+}
+
+// navigate runs a navigation action and waits for the lifecycle event of the new document in the main frame.
+// The action returns the loader ID of the navigation if known; an empty loader ID means
+// the first document started after the action is waited for. Navigations within the document and
+// restores from the back/forward cache fire no lifecycle events and complete immediately.
+func (p *Page) navigate(waitUntil WaitUntil, action func(context.Context) (cdp.LoaderID, bool, error)) error {
+	return chromedp.Run(p, chromedp.ActionFunc(func(ctx context.Context) error {
+		frameID, err := mainFrame(ctx)
+		if err != nil {
+			return err
+		}
+
+		lctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		ch := make(chan *page.EventLifecycleEvent, DefaultChannelBufferCapacity)
+		settled := make(chan struct{}, 1)
+		chromedp.ListenTarget(lctx, func(v any) {
+			switch ev := v.(type) {
+			case *page.EventLifecycleEvent:
+				if ev.FrameID == frameID {
+					select {
+					case ch <- ev:
+					case <-lctx.Done():
+					}
+				}
+			case *page.EventNavigatedWithinDocument:
+				if ev.FrameID == frameID {
+					select {
+					case settled <- struct{}{}:
+					default:
+					}
+				}
+			case *page.EventFrameNavigated:
+				if ev.Frame.ID == frameID && ev.Type == page.NavigationTypeBackForwardCacheRestore {
+					select {
+					case settled <- struct{}{}:
+					default:
+					}
+				}
+			}
+		})
+
+		loaderID, wait, err := action(ctx)
+		if err != nil || !wait {
+			return err
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-settled:
+				if loaderID == "" {
+					return nil
+				}
+			case ev := <-ch:
+				if loaderID == "" && ev.Name == "init" {
+					loaderID = ev.LoaderID
+				}
+				if ev.LoaderID == loaderID && ev.Name == waitUntil.String() {
+					return nil
+				}
+			}
+		}
+	}))
+}
+
+// Goto navigates the page to url and waits for the specified lifecycle event.
+// If a NavigationGuard blocks the navigation, a *DisallowedError is returned.
+func (p *Page) Goto(url string, waitUntil WaitUntil) error {
+	return p.navigate(waitUntil, func(ctx context.Context) (cdp.LoaderID, bool, error) {
+		resetBlocked(ctx)
+		frameID, loaderID, errorText, _, err := page.Navigate(url).Do(ctx)
+		if err != nil {
+			return "", false, err
+		} else if errorText != "" {
+			if err := blocked(ctx, frameID); err != nil {
+				return "", false, err
+			}
+			return "", false, fmt.Errorf("page load error %s", errorText)
+		}
+		// An empty loader ID indicates a same-document navigation which fires no lifecycle events.
+		return loaderID, loaderID != "", nil
+	})
+}
+
+// Reload reloads the page and waits for the specified lifecycle event.
+func (p *Page) Reload(waitUntil WaitUntil) error {
+	return p.navigate(waitUntil, func(ctx context.Context) (cdp.LoaderID, bool, error) {
+		return "", true, page.Reload().Do(ctx)
+	})
+}
+
+// ErrNoHistoryEntry is returned by Back and Forward when there is no history entry to navigate to.
+var ErrNoHistoryEntry = errors.New("no history entry")
+
+// history navigates to the history entry at the offset from the current one.
+func (p *Page) history(offset int64, waitUntil WaitUntil) error {
+	return p.navigate(waitUntil, func(ctx context.Context) (cdp.LoaderID, bool, error) {
+		current, entries, err := page.GetNavigationHistory().Do(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		i := current + offset
+		if i < 0 || i >= int64(len(entries)) {
+			return "", false, ErrNoHistoryEntry
+		}
+		return "", true, page.NavigateToHistoryEntry(entries[i].ID).Do(ctx)
+	})
+}
+
+// Back navigates to the previous history entry and waits for the specified lifecycle event.
+func (p *Page) Back(waitUntil WaitUntil) error {
+	return p.history(-1, waitUntil)
+}
+
+// Forward navigates to the next history entry and waits for the specified lifecycle event.
+func (p *Page) Forward(waitUntil WaitUntil) error {
+	return p.history(1, waitUntil)
+}
+
+// Title returns the title of the current document.
+func (p *Page) Title() (title string, err error) {
+	err = chromedp.Run(p, chromedp.Title(&title))
+	return
+}
+
+// URL returns the URL of the current document.
+func (p *Page) URL() (url string, err error) {
+	err = chromedp.Run(p, chromedp.Location(&url))
+	return
+}
+
+// Content returns the full HTML of the current document, including the doctype.
+func (p *Page) Content() (html string, err error) {
+	err = chromedp.Run(p, chromedp.ActionFunc(func(ctx context.Context) error {
+		root, err := dom.GetDocument().Do(ctx)
+		if err != nil {
+			return err
+		}
+		html, err = dom.GetOuterHTML().WithNodeID(root.NodeID).Do(ctx)
+		return err
+	}))
+	return
+}
+
+// SetContent replaces the document of the page with the given HTML.
+func (p *Page) SetContent(html string) error {
+	return chromedp.Run(p, chromedp.ActionFunc(func(ctx context.Context) error {
+		frameID, err := mainFrame(ctx)
+		if err != nil {
+			return err
+		}
+		return page.SetDocumentContent(frameID, html).Do(ctx)
+	}))
+}
+
+// Run executes the provided chromedp actions in the page.
+func (p *Page) Run(actions ...chromedp.Action) error {
+	return chromedp.Run(p, actions...)
+}
+
+// Close closes the page.
+func (p *Page) Close() {
+	p.stop()
+	p.cancel()
+}
@@ -0,0 +1,88 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "<!DOCTYPE html><html><head><title>%s</title></head><body>Test</body></html>", r.URL.Path)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	p, err := c.NewPage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	for _, i := range []struct {
+		path      string
+		waitUntil WaitUntil
+	}{
+		{"/a", WaitUntilLoad},
+		{"/b", WaitUntilDOMContentLoaded},
+		{"/c", WaitUntilNetworkIdle},
+	} {
+		if err := p.Goto(ts.URL+i.path, i.waitUntil); err != nil {
+			t.Fatalf("%s: %v", i.waitUntil, err)
+		}
+		if title, err := p.Title(); err != nil {
+			t.Fatal(err)
+		} else if title != i.path {
+			t.Errorf("expected %q; got %q", i.path, title)
+		}
+	}
+
+	if err := p.Back(WaitUntilLoad); err != nil {
+		t.Fatal(err)
+	}
+	if url, err := p.URL(); err != nil {
+		t.Fatal(err)
+	} else if expect := ts.URL + "/b"; url != expect {
+		t.Errorf("expected %q; got %q", expect, url)
+	}
+	if err := p.Forward(WaitUntilLoad); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Forward(WaitUntilLoad); err != ErrNoHistoryEntry {
+		t.Errorf("expected ErrNoHistoryEntry; got %v", err)
+	}
+	if err := p.Reload(WaitUntilLoad); err != nil {
+		t.Fatal(err)
+	}
+
+	// History entries within the same document fire no lifecycle events.
+	if err := p.Goto(ts.URL+"/c#top", WaitUntilLoad); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Back(WaitUntilLoad); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Forward(WaitUntilLoad); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.SetContent("<html><body><p>content</p></body></html>"); err != nil {
+		t.Fatal(err)
+	}
+	html, err := p.Content()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, "<p>content</p>") {
+		t.Errorf("expected content in %q", html)
+	}
+}
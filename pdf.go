@@ -0,0 +1,158 @@
+package chrome
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+
+	"github.com/chromedp/cdproto/cdp"
+	cdpio "github.com/chromedp/cdproto/io"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// PaperSize represents paper dimensions in inches.
+type PaperSize struct {
+	Width  float64
+	Height float64
+}
+
+// Common paper sizes.
+var (
+	PaperLetter  = PaperSize{8.5, 11}
+	PaperLegal   = PaperSize{8.5, 14}
+	PaperTabloid = PaperSize{11, 17}
+	PaperA3      = PaperSize{11.69, 16.54}
+	PaperA4      = PaperSize{8.27, 11.69}
+	PaperA5      = PaperSize{5.83, 8.27}
+)
+
+// PDFOptions configures PDF generation.
+// Zero values use the browser defaults, except margins which default to none.
+type PDFOptions struct {
+	Paper             PaperSize // Paper size, defaults to Letter
+	Landscape         bool      // Paper orientation
+	MarginTop         float64   // Top margin in inches
+	MarginBottom      float64   // Bottom margin in inches
+	MarginLeft        float64   // Left margin in inches
+	MarginRight       float64   // Right margin in inches
+	Scale             float64   // Scale of the webpage rendering, defaults to 1
+	PrintBackground   bool      // Print background graphics
+	HeaderTemplate    string    // HTML template for the print header; enables header and footer when set, empty if only the footer is set
+	FooterTemplate    string    // HTML template for the print footer; enables header and footer when set, empty if only the header is set
+	PageRanges        string    // Pages to print, e.g. "1-5, 8, 11-13", defaults to all pages
+	PreferCSSPageSize bool      // Prefer page size as defined by CSS
+	NoTaggedPDF       bool      // Do not generate a tagged (accessible) PDF
+	NoOutline         bool      // Do not embed the document outline
+}
+
+// emptyPDFTemplate is an empty header or footer template.
+const emptyPDFTemplate = "<span></span>"
+
+// params converts the options to Page.printToPDF parameters.
+func (opts *PDFOptions) params() *page.PrintToPDFParams {
+	if opts == nil {
+		opts = new(PDFOptions)
+	}
+	// With only one template set, the other would fall back to the browser's default header or footer.
+	header, footer := opts.HeaderTemplate, opts.FooterTemplate
+	display := header != "" || footer != ""
+	if display {
+		if header == "" {
+			header = emptyPDFTemplate
+		}
+		if footer == "" {
+			footer = emptyPDFTemplate
+		}
+	}
+	return &page.PrintToPDFParams{
+		Landscape:               opts.Landscape,
+		DisplayHeaderFooter:     display,
+		PrintBackground:         opts.PrintBackground,
+		Scale:                   opts.Scale,
+		PaperWidth:              opts.Paper.Width,
+		PaperHeight:             opts.Paper.Height,
+		MarginTop:               opts.MarginTop,
+		MarginBottom:            opts.MarginBottom,
+		MarginLeft:              opts.MarginLeft,
+		MarginRight:             opts.MarginRight,
+		PageRanges:              opts.PageRanges,
+		HeaderTemplate:          header,
+		FooterTemplate:          footer,
+		PreferCSSPageSize:       opts.PreferCSSPageSize,
+		TransferMode:            page.PrintToPDFTransferModeReturnAsStream,
+		GenerateTaggedPDF:       !opts.NoTaggedPDF,
+		GenerateDocumentOutline: !opts.NoOutline,
+	}
+}
+
+// streamChunkSize is the maximum number of bytes requested per IO.read call.
+const streamChunkSize = 1 << 20
+
+// readStream copies the content of a protocol stream to w and closes the stream.
+func readStream(ctx context.Context, stream cdpio.StreamHandle, w io.Writer) (err error) {
+	defer func() {
+		if e := cdpio.Close(stream).Do(ctx); err == nil {
+			err = e
+		}
+	}()
+	for {
+		var res cdpio.ReadReturns
+		if err = cdp.Execute(ctx, cdpio.CommandRead, cdpio.Read(stream).WithSize(streamChunkSize), &res); err != nil {
+			return
+		}
+		b := []byte(res.Data)
+		if res.Base64encoded {
+			if b, err = base64.StdEncoding.DecodeString(res.Data); err != nil {
+				return
+			}
+		}
+		if _, err = w.Write(b); err != nil {
+			return
+		}
+		if res.EOF {
+			return
+		}
+	}
+}
+
+// printPDF returns an action printing the page as PDF and streaming it to w.
+func printPDF(opts *PDFOptions, w io.Writer) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		_, stream, err := opts.params().Do(ctx)
+		if err != nil {
+			return err
+		}
+		return readStream(ctx, stream, w)
+	})
+}
+
+// PrintPDF prints the page as PDF with the given options and streams it to w.
+// A nil opts uses the default options.
+func PrintPDF(ctx context.Context, opts *PDFOptions, w io.Writer) error {
+	return chromedp.Run(ctx, printPDF(opts, w))
+}
+
+// SavePDF prints the page as PDF and saves it to the named file.
+func SavePDF(ctx context.Context, opts *PDFOptions, name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := PrintPDF(ctx, opts, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// PrintPDF prints the page of this Chrome instance as PDF and streams it to w.
+func (c *Chrome) PrintPDF(opts *PDFOptions, w io.Writer) error {
+	return PrintPDF(c, opts, w)
+}
+
+// SavePDF prints the page of this Chrome instance as PDF and saves it to the named file.
+func (c *Chrome) SavePDF(opts *PDFOptions, name string) error {
+	return SavePDF(c, opts, name)
+}
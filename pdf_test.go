@@ -0,0 +1,60 @@
+package chrome
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestPrintPDF(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<h1>Title</h1><div style="height:5000px">Test</div>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := PrintPDF(ctx, &PDFOptions{
+		Paper:          PaperA4,
+		Landscape:      true,
+		MarginTop:      0.5,
+		MarginBottom:   0.5,
+		FooterTemplate: `<span class="pageNumber"></span>`,
+		PageRanges:     "1-2",
+	}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Errorf("expected PDF header; got %q", buf.Bytes()[:min(buf.Len(), 8)])
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("%%EOF")) {
+		t.Error("expected complete PDF")
+	}
+}
+
+func TestPDFParams(t *testing.T) {
+	if p := (*PDFOptions)(nil).params(); p.DisplayHeaderFooter || p.HeaderTemplate != "" || p.FooterTemplate != "" {
+		t.Errorf("expected no header and footer; got %v %q %q", p.DisplayHeaderFooter, p.HeaderTemplate, p.FooterTemplate)
+	}
+	if p := (&PDFOptions{FooterTemplate: "footer"}).params(); !p.DisplayHeaderFooter || p.HeaderTemplate != "<span></span>" || p.FooterTemplate != "footer" {
+		t.Errorf("expected empty header; got %v %q %q", p.DisplayHeaderFooter, p.HeaderTemplate, p.FooterTemplate)
+	}
+	if p := (&PDFOptions{HeaderTemplate: "header"}).params(); p.HeaderTemplate != "header" || p.FooterTemplate != "<span></span>" {
+		t.Errorf("expected empty footer; got %q %q", p.HeaderTemplate, p.FooterTemplate)
+	}
+}
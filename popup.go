@@ -0,0 +1,73 @@
+package chrome
+
+import (
+	"context"
+	"strings"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// waitLoadScript resolves once the document has fired its load event.
+const waitLoadScript = `new Promise(resolve => document.readyState == "complete" ?
+	resolve(true) : addEventListener("load", () => resolve(true), { once: true }))`
+
+// waitLoad returns an action waiting for the load event of the current document.
+// The evaluation is retried once if the execution context is replaced by a navigation.
+func waitLoad() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		evaluate := chromedp.Evaluate(waitLoadScript, nil, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+			return p.WithAwaitPromise(true)
+		})
+		err := evaluate.Do(ctx)
+		if err != nil && strings.Contains(err.Error(), "context was destroyed") {
+			err = evaluate.Do(ctx)
+		}
+		return err
+	})
+}
+
+// isPopupTarget reports whether a target is a page opened by the web content,
+// excluding service workers, extension pages and other target types.
+func isPopupTarget(info *target.Info) bool {
+	return info.Type == "page" && !strings.HasPrefix(info.URL, "chrome-extension://")
+}
+
+// ExpectPopup runs trigger in ctx and waits for a popup or new tab opened by it whose URL matches url.
+// The url argument accepts the same matchers as ListenEvent. The returned context is attached to
+// the new page after actions have run on it and its load event has fired; cancel releases it.
+func ExpectPopup(ctx context.Context, url any, trigger chromedp.Action, actions ...chromedp.Action) (context.Context, context.CancelFunc, error) {
+	wctx, wcancel := context.WithCancel(ctx)
+	defer wcancel()
+
+	ch := chromedp.WaitNewTarget(wctx, func(info *target.Info) bool {
+		return isPopupTarget(info) && match(info.URL, url)
+	})
+	if err := chromedp.Run(ctx, trigger); err != nil {
+		return nil, nil, err
+	}
+	var id target.ID
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case id = <-ch:
+	}
+
+	pctx, cancel := chromedp.NewContext(ctx, chromedp.WithTargetID(id))
+	if err := chromedp.Run(pctx, actions...); err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	if err := chromedp.Run(pctx, waitLoad()); err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return pctx, cancel, nil
+}
+
+// ExpectPopup runs trigger in ctx and returns a context attached to the matching popup,
+// applying the startup actions of this Chrome instance to it.
+func (c *Chrome) ExpectPopup(ctx context.Context, url any, trigger chromedp.Action) (context.Context, context.CancelFunc, error) {
+	return ExpectPopup(ctx, url, trigger, c.actions...)
+}
@@ -0,0 +1,64 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+func TestIsPopupTarget(t *testing.T) {
+	for _, tc := range []struct {
+		info   *target.Info
+		expect bool
+	}{
+		{&target.Info{Type: "page", URL: "https://example.com/"}, true},
+		{&target.Info{Type: "service_worker", URL: "https://example.com/sw.js"}, false},
+		{&target.Info{Type: "page", URL: "chrome-extension://abc/popup.html"}, false},
+		{&target.Info{Type: "background_page", URL: "chrome-extension://abc/bg.html"}, false},
+	} {
+		if res := isPopupTarget(tc.info); res != tc.expect {
+			t.Errorf("%v: expected %v; got %v", tc.info.URL, tc.expect, res)
+		}
+	}
+}
+
+func TestExpectPopup(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/popup" {
+			fmt.Fprint(w, "<!DOCTYPE html><html><head><title>popup</title></head><body></body></html>")
+			return
+		}
+		fmt.Fprint(w, `<!DOCTYPE html><html><body><a id="open" href="/popup" target="_blank">open</a></body></html>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	popup, popupCancel, err := c.ExpectPopup(ctx, URLHasSuffix("/popup"), chromedp.Click("#open", chromedp.ByID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer popupCancel()
+
+	var title string
+	if err := chromedp.Run(popup, chromedp.Title(&title)); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "popup"; title != expect {
+		t.Errorf("expected %q; got %q", expect, title)
+	}
+}
@@ -0,0 +1,180 @@
+package chrome
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"html/template"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// waitResourcesScript resolves once web fonts and all images of the document have loaded or failed.
+const waitResourcesScript = `(async () => {
+	await document.fonts.ready;
+	await Promise.all(Array.from(document.images, img => img.complete ? null : new Promise(resolve => {
+		img.addEventListener("load", resolve);
+		img.addEventListener("error", resolve);
+	})));
+	return true;
+})()`
+
+// waitResources returns an action waiting for fonts and images of the document to load.
+func waitResources() chromedp.Action {
+	return chromedp.Evaluate(waitResourcesScript, nil, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	})
+}
+
+// ServeFS serves requests under baseURL from fsys by intercepting them with the Fetch API.
+// The base URL itself serves index.html if present, otherwise an empty document.
+// Missing files are answered with 404 Not Found and other read errors with 500 Internal Server Error.
+func ServeFS(ctx context.Context, baseURL string, fsys fs.FS) error {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	return HandleFetch(ctx, func(ctx context.Context, ev *fetch.EventRequestPaused) error {
+		u, err := url.Parse(ev.Request.URL)
+		if err != nil || u.Scheme != base.Scheme || u.Host != base.Host || !strings.HasPrefix(u.Path, base.Path) {
+			return fetch.ContinueRequest(ev.RequestID).Do(ctx)
+		}
+		name := strings.TrimPrefix(u.Path, base.Path)
+		if name = path.Clean("/" + name)[1:]; name == "" {
+			name = "index.html"
+		}
+		ctype := mime.TypeByExtension(path.Ext(name))
+		b, err := fs.ReadFile(fsys, name)
+		code := http.StatusOK
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				code, b = http.StatusInternalServerError, []byte(err.Error())
+				ctype = "text/plain; charset=utf-8"
+			} else if name != "index.html" {
+				code = http.StatusNotFound
+			}
+		}
+		if ctype == "" {
+			ctype = http.DetectContentType(b)
+		}
+		return fetch.FulfillRequest(ev.RequestID, int64(code)).
+			WithResponseHeaders([]*fetch.HeaderEntry{
+				{Name: "Content-Type", Value: ctype},
+				{Name: "Access-Control-Allow-Origin", Value: "*"},
+			}).
+			WithBody(base64.StdEncoding.EncodeToString(b)).
+			Do(ctx)
+	}, &fetch.RequestPattern{URLPattern: fetchEscaper.Replace(baseURL) + "*"})
+}
+
+// defaultRenderBaseURL is the base URL of RenderOptions.FS when no base URL is given.
+const defaultRenderBaseURL = "http://render.localhost/"
+
+// RenderOptions configures the output of RenderHTML and RenderTemplate.
+type RenderOptions struct {
+	FS         fs.FS              // Serves relative assets under the base URL, see ServeFS
+	PDF        *PDFOptions        // PDF options, nil for the defaults
+	Screenshot *ScreenshotOptions // Captures a screenshot instead of a PDF when set
+}
+
+// RenderHTML loads html into the page with Page.setDocumentContent, waits for its fonts and images
+// to load, and writes it to w as PDF, or as a screenshot if opts.Screenshot is set. A nil opts prints
+// a PDF with the default options.
+// If baseURL is not empty, the page is first navigated to it without a network request, so that
+// relative URLs resolve against it; with opts.FS they are served from fsys for the duration of the
+// call, and baseURL defaults to http://render.localhost/. Otherwise the current document URL is kept.
+func RenderHTML(ctx context.Context, html string, baseURL string, opts *RenderOptions, w io.Writer) error {
+	if opts == nil {
+		opts = new(RenderOptions)
+	}
+	if baseURL == "" && opts.FS != nil {
+		baseURL = defaultRenderBaseURL
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var actions []chromedp.Action
+	if baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		if u.Path == "" {
+			u.Path = "/"
+		}
+		u.Fragment = ""
+		baseURL = u.String()
+		if opts.FS != nil {
+			if err := ServeFS(ctx, baseURL[:strings.LastIndex(baseURL, "/")+1], opts.FS); err != nil {
+				return err
+			}
+		}
+		// The document at baseURL is only a placeholder for the content.
+		if err := HandleFetch(ctx, func(ctx context.Context, ev *fetch.EventRequestPaused) error {
+			return fetch.FulfillRequest(ev.RequestID, http.StatusOK).
+				WithResponseHeaders([]*fetch.HeaderEntry{{Name: "Content-Type", Value: "text/html"}}).
+				Do(ctx)
+		}, &fetch.RequestPattern{URLPattern: fetchEscaper.Replace(baseURL), ResourceType: network.ResourceTypeDocument}); err != nil {
+			return err
+		}
+		actions = append(actions, chromedp.Navigate(baseURL))
+	}
+	actions = append(actions,
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			frameID, err := mainFrame(ctx)
+			if err != nil {
+				return err
+			}
+			return page.SetDocumentContent(frameID, html).Do(ctx)
+		}),
+		waitResources(),
+	)
+	if opts.Screenshot != nil {
+		var b []byte
+		if err := chromedp.Run(ctx, append(actions, screenshot(opts.Screenshot, &b))...); err != nil {
+			return err
+		}
+		_, err := w.Write(b)
+		return err
+	}
+	return chromedp.Run(ctx, append(actions, printPDF(opts.PDF, w))...)
+}
+
+// RenderTemplate executes tmpl with data and renders the result like RenderHTML.
+func RenderTemplate(ctx context.Context, tmpl *template.Template, data any, baseURL string, opts *RenderOptions, w io.Writer) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	return RenderHTML(ctx, buf.String(), baseURL, opts, w)
+}
+
+// ServeFS serves requests under baseURL from fsys on this Chrome instance.
+func (c *Chrome) ServeFS(baseURL string, fsys fs.FS) error {
+	return ServeFS(c, baseURL, fsys)
+}
+
+// RenderHTML renders html in the page of this Chrome instance and writes it to w.
+func (c *Chrome) RenderHTML(html string, baseURL string, opts *RenderOptions, w io.Writer) error {
+	return RenderHTML(c, html, baseURL, opts, w)
+}
+
+// RenderTemplate executes tmpl with data, renders the result in the page of this Chrome instance and writes it to w.
+func (c *Chrome) RenderTemplate(tmpl *template.Template, data any, baseURL string, opts *RenderOptions, w io.Writer) error {
+	return RenderTemplate(c, tmpl, data, baseURL, opts, w)
+}
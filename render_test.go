@@ -0,0 +1,84 @@
+package chrome
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"image"
+	"image/png"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+func TestRender(t *testing.T) {
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 40, 30))); err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{"assets/my logo.png": {Data: img.Bytes()}}
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	tmpl := template.Must(template.New("invoice").Parse(`<h1>{{.}}</h1><img id="logo" src="assets/my%20logo.png?v=1#top">`))
+	var pdf bytes.Buffer
+	if err := RenderTemplate(ctx, tmpl, "Invoice", "", &RenderOptions{FS: fsys}, &pdf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(pdf.Bytes(), []byte("%PDF-")) {
+		t.Error("expected PDF output")
+	}
+
+	var width int
+	var title string
+	if err := chromedp.Run(ctx,
+		chromedp.Evaluate(`document.getElementById("logo").naturalWidth`, &width),
+		chromedp.Text("h1", &title),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if width != 40 {
+		t.Errorf("expected image width 40; got %d", width)
+	}
+	if title != "Invoice" {
+		t.Errorf("expected %q; got %q", "Invoice", title)
+	}
+
+	// The base URL is never requested from the network.
+	var shot bytes.Buffer
+	if err := RenderHTML(ctx, `<img id="logo" src="logo.png">`, "http://render.invalid/assets/invoice.html",
+		&RenderOptions{FS: fstest.MapFS{"logo.png": {Data: img.Bytes()}}, Screenshot: &ScreenshotOptions{Selector: "#logo"}}, &shot); err != nil {
+		t.Fatal(err)
+	}
+	if config, err := png.DecodeConfig(&shot); err != nil {
+		t.Fatal(err)
+	} else if config.Width != 40 || config.Height != 30 {
+		t.Errorf("expected 40x30; got %dx%d", config.Width, config.Height)
+	}
+
+	// Missing files are 404 and other read errors 500.
+	sctx, scancel := context.WithCancel(ctx)
+	defer scancel()
+	if err := ServeFS(sctx, "http://fs.localhost/", fsys); err != nil {
+		t.Fatal(err)
+	}
+	var codes []int
+	if err := chromedp.Run(sctx,
+		chromedp.Navigate("http://fs.localhost/"),
+		chromedp.Evaluate(`Promise.all(["assets/my%20logo.png", "missing.png", "assets"].map(async p => (await fetch(p)).status))`, &codes,
+			func(p *runtime.EvaluateParams) *runtime.EvaluateParams { return p.WithAwaitPromise(true) }),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if expect := []int{200, 404, 500}; !slices.Equal(codes, expect) {
+		t.Errorf("expected %v; got %v", expect, codes)
+	}
+}
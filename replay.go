@@ -0,0 +1,151 @@
+package chrome
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+)
+
+// ReplayOptions configures a HAR replay.
+type ReplayOptions struct {
+	MatchBody   bool // Also require the request body to match the recorded post data
+	PassThrough bool // Let unmatched requests go to the network instead of failing them
+}
+
+// ReplayReport records the requests that had no matching HAR entry during a replay.
+type ReplayReport struct {
+	mu     sync.Mutex
+	misses []string
+}
+
+// Misses returns the unmatched requests as "METHOD URL" strings.
+func (r *ReplayReport) Misses() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.misses)
+}
+
+// replayKey returns the lookup key of a request.
+func replayKey(method, url, body string, matchBody bool) string {
+	if i := strings.Index(url, "#"); i >= 0 {
+		url = url[:i]
+	}
+	key := strings.ToUpper(method) + " " + url
+	if matchBody {
+		key += "\n" + body
+	}
+	return key
+}
+
+// harReplayer serves recorded responses in order, repeating the last one once exhausted.
+type harReplayer struct {
+	mu      sync.Mutex
+	entries map[string][]*HAREntry
+	served  map[string]int
+}
+
+// newHARReplayer indexes the entries of a HAR document.
+func newHARReplayer(har *HAR, matchBody bool) *harReplayer {
+	r := &harReplayer{entries: make(map[string][]*HAREntry), served: make(map[string]int)}
+	if har == nil || har.Log == nil {
+		return r
+	}
+	for _, e := range har.Log.Entries {
+		if e.Request == nil || e.Response == nil {
+			continue
+		}
+		var body string
+		if e.Request.PostData != nil {
+			body = e.Request.PostData.Text
+		}
+		key := replayKey(e.Request.Method, e.Request.URL, body, matchBody)
+		r.entries[key] = append(r.entries[key], e)
+	}
+	return r
+}
+
+// lookup returns the next entry for the key.
+func (r *harReplayer) lookup(key string) *HAREntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := r.entries[key]
+	if len(entries) == 0 {
+		return nil
+	}
+	i := min(r.served[key], len(entries)-1)
+	r.served[key]++
+	return entries[i]
+}
+
+// replayHeaders returns the recorded response headers suitable for fulfilling a request.
+// The recorded body is decoded, so content and transfer encodings are dropped.
+func replayHeaders(resp *HARResponse) []*fetch.HeaderEntry {
+	var headers []*fetch.HeaderEntry
+	for _, i := range resp.Headers {
+		switch strings.ToLower(i.Name) {
+		case "content-encoding", "content-length", "transfer-encoding":
+			continue
+		}
+		headers = append(headers, &fetch.HeaderEntry{Name: i.Name, Value: i.Value})
+	}
+	return headers
+}
+
+// ReplayHAR fulfills requests from the entries of a HAR file using Fetch interception.
+// Requests are matched by method and URL, and optionally by body. Repeated requests are served
+// the recorded responses in order. Unmatched requests fail unless PassThrough is set, and are
+// listed in the returned report. A nil opts uses the default options.
+func ReplayHAR(ctx context.Context, harFile string, opts *ReplayOptions) (*ReplayReport, error) {
+	if opts == nil {
+		opts = new(ReplayOptions)
+	}
+	b, err := os.ReadFile(harFile)
+	if err != nil {
+		return nil, err
+	}
+	var har HAR
+	if err := json.Unmarshal(b, &har); err != nil {
+		return nil, err
+	}
+	replayer := newHARReplayer(&har, opts.MatchBody)
+	report := new(ReplayReport)
+	if err := HandleFetch(ctx, func(ctx context.Context, ev *fetch.EventRequestPaused) error {
+		e := replayer.lookup(replayKey(ev.Request.Method, ev.Request.URL, string(postData(ev.Request)), opts.MatchBody))
+		if e == nil {
+			report.mu.Lock()
+			report.misses = append(report.misses, ev.Request.Method+" "+ev.Request.URL)
+			report.mu.Unlock()
+			if opts.PassThrough {
+				return fetch.ContinueRequest(ev.RequestID).Do(ctx)
+			}
+			return fetch.FailRequest(ev.RequestID, network.ErrorReasonInternetDisconnected).Do(ctx)
+		}
+		if e.Response.Status == 0 {
+			return fetch.FailRequest(ev.RequestID, network.ErrorReasonFailed).Do(ctx)
+		}
+		params := fetch.FulfillRequest(ev.RequestID, e.Response.Status).WithResponseHeaders(replayHeaders(e.Response))
+		if e.Response.Content != nil {
+			body, err := e.Response.Content.Bytes()
+			if err != nil {
+				return err
+			}
+			params = params.WithBody(base64.StdEncoding.EncodeToString(body))
+		}
+		return params.Do(ctx)
+	}); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ReplayHAR fulfills requests of this Chrome instance from the entries of a HAR file.
+func (c *Chrome) ReplayHAR(harFile string, opts *ReplayOptions) (*ReplayReport, error) {
+	return ReplayHAR(c, harFile, opts)
+}
@@ -0,0 +1,82 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestHARReplayer(t *testing.T) {
+	har := &HAR{&HARLog{Entries: []*HAREntry{
+		{Request: &HARRequest{Method: "GET", URL: "https://example.com/a"}, Response: &HARResponse{Status: 200, StatusText: "first"}},
+		{Request: &HARRequest{Method: "GET", URL: "https://example.com/a"}, Response: &HARResponse{Status: 200, StatusText: "second"}},
+		{Request: &HARRequest{Method: "POST", URL: "https://example.com/b", PostData: &HARPostData{Text: "x=1"}}, Response: &HARResponse{Status: 201}},
+	}}}
+
+	r := newHARReplayer(har, false)
+	for _, expect := range []string{"first", "second", "second"} {
+		if e := r.lookup(replayKey("GET", "https://example.com/a#top", "", false)); e == nil || e.Response.StatusText != expect {
+			t.Errorf("expected %q; got %v", expect, e)
+		}
+	}
+	if e := r.lookup(replayKey("post", "https://example.com/b", "x=2", false)); e == nil {
+		t.Error("expected match without body")
+	}
+
+	r = newHARReplayer(har, true)
+	if e := r.lookup(replayKey("POST", "https://example.com/b", "x=2", true)); e != nil {
+		t.Error("expected no match with different body")
+	}
+	if e := r.lookup(replayKey("POST", "https://example.com/b", "x=1", true)); e == nil {
+		t.Error("expected match with same body")
+	}
+}
+
+func TestReplayHAR(t *testing.T) {
+	base := "http://replay.localhost/"
+	har := &HAR{&HARLog{Version: "1.2", Entries: []*HAREntry{
+		{
+			Request: &HARRequest{Method: "GET", URL: base},
+			Response: &HARResponse{
+				Status:  200,
+				Headers: []*HARNameValue{{Name: "Content-Type", Value: "text/html"}},
+				Content: &HARContent{MimeType: "text/html", Text: `<p id="text">replayed</p><img src="missing.png">`},
+			},
+		},
+	}}}
+	b, err := json.Marshal(har)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(t.TempDir(), "test.har")
+	if err := os.WriteFile(name, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	report, err := ReplayHAR(ctx, name, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var text string
+	if err := chromedp.Run(ctx, chromedp.Navigate(base), chromedp.Text("#text", &text)); err != nil {
+		t.Fatal(err)
+	}
+	if text != "replayed" {
+		t.Errorf("expected %q; got %q", "replayed", text)
+	}
+	if misses := report.Misses(); !slices.Contains(misses, "GET "+base+"missing.png") {
+		t.Errorf("expected missing.png reported; got %v", misses)
+	}
+}
@@ -0,0 +1,395 @@
+package chrome
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// robotsTTL is how long a fetched robots.txt is cached.
+const robotsTTL = 24 * time.Hour
+
+// robotsTimeout bounds fetching a robots.txt, which navigations to its origin wait for.
+var robotsTimeout = 30 * time.Second
+
+// DisallowedError is returned for URLs disallowed by robots.txt.
+type DisallowedError struct {
+	URL       string // Disallowed URL
+	UserAgent string // User agent token the rules were evaluated for
+}
+
+// Error implements the error interface.
+func (e *DisallowedError) Error() string {
+	return fmt.Sprintf("disallowed by robots.txt for %s: %s", e.UserAgent, e.URL)
+}
+
+// robotsRule is an allow or disallow rule of a robots.txt group.
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// robotsRules are the rules of robots.txt that apply to a user agent.
+type robotsRules struct {
+	rules []robotsRule
+	delay time.Duration // Crawl-delay of the group, zero if not specified
+}
+
+// parseRobots parses robots.txt as specified by RFC 9309 and returns the rules of the groups
+// matching the user agent token, falling back to the groups for "*".
+// The non-standard Crawl-delay directive is also recognized.
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	userAgent = strings.ToLower(userAgent)
+	var specific, star robotsRules
+	var foundSpecific bool
+	var matchSpecific, matchAny, inRules bool
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line, _, _ := strings.Cut(s.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		switch key {
+		case "user-agent":
+			if inRules {
+				matchSpecific, matchAny, inRules = false, false, false
+			}
+			switch agent := strings.ToLower(value); agent {
+			case "*":
+				matchAny = true
+			case userAgent:
+				matchSpecific, foundSpecific = true, true
+			}
+		case "allow", "disallow", "crawl-delay":
+			inRules = true
+			var target []*robotsRules
+			if matchSpecific {
+				target = append(target, &specific)
+			}
+			if matchAny {
+				target = append(target, &star)
+			}
+			for _, t := range target {
+				if key == "crawl-delay" {
+					if d, err := strconv.ParseFloat(value, 64); err == nil && d > 0 {
+						t.delay = time.Duration(d * float64(time.Second))
+					}
+				} else if value != "" {
+					t.rules = append(t.rules, robotsRule{key == "allow", value})
+				}
+			}
+		}
+	}
+	if foundSpecific {
+		return &specific
+	}
+	return &star
+}
+
+// robotsMatch reports whether path matches a robots.txt pattern supporting * and $.
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	if len(parts) == 1 {
+		return !anchored || path == pattern
+	}
+	pos := len(parts[0])
+	for i, part := range parts[1:] {
+		if anchored && i == len(parts)-2 {
+			return strings.HasSuffix(path[pos:], part)
+		}
+		j := strings.Index(path[pos:], part)
+		if j < 0 {
+			return false
+		}
+		pos += j + len(part)
+	}
+	return true
+}
+
+// allowed reports whether path is allowed. The longest matching rule wins and allow wins ties.
+func (r *robotsRules) allowed(path string) bool {
+	if path == "/robots.txt" {
+		return true
+	}
+	allow, length := true, -1
+	for _, rule := range r.rules {
+		if robotsMatch(rule.pattern, path) {
+			if n := len(rule.pattern); n > length || (n == length && rule.allow) {
+				allow, length = rule.allow, n
+			}
+		}
+	}
+	return allow
+}
+
+// robotsEntry is a cached robots.txt of an origin.
+type robotsEntry struct {
+	ready   chan struct{}
+	rules   *robotsRules
+	err     error
+	expires time.Time
+}
+
+// NavigationGuard enforces robots.txt rules and per-host rate limits on navigations.
+// A single guard may be shared by several Chrome instances to limit them together.
+type NavigationGuard struct {
+	userAgent string
+	interval  time.Duration
+	client    *http.Client // nil uses http.DefaultClient, or a client for the proxy of the tab
+
+	mu      sync.Mutex
+	robots  map[string]*robotsEntry // by origin
+	hosts   map[string]time.Time    // earliest time of the next request per host
+	proxies map[string]*http.Client // by proxy server
+}
+
+// NewNavigationGuard returns a NavigationGuard evaluating robots.txt for the user agent token
+// and allowing at most one request per interval to each host. A larger Crawl-delay in robots.txt
+// takes precedence over interval.
+func NewNavigationGuard(userAgent string, interval time.Duration) *NavigationGuard {
+	return &NavigationGuard{
+		userAgent: userAgent,
+		interval:  interval,
+		robots:    make(map[string]*robotsEntry),
+		hosts:     make(map[string]time.Time),
+		proxies:   make(map[string]*http.Client),
+	}
+}
+
+// SetHTTPClient sets the client used to fetch robots.txt. By default http.DefaultClient is used,
+// or for tabs of a Chrome instance with a Proxy, a client sending the requests through that proxy.
+// Each fetch is limited to 30 seconds regardless of the client's timeout.
+func (g *NavigationGuard) SetHTTPClient(client *http.Client) *NavigationGuard {
+	g.client = client
+	return g
+}
+
+// rules returns the cached robots.txt rules of the origin of u, fetching them if needed.
+// Following RFC 9309, a missing robots.txt allows everything and an unreachable one disallows everything.
+// proxy is the proxy server of the tab, if any.
+func (g *NavigationGuard) rules(ctx context.Context, u *url.URL, proxy string) (*robotsRules, error) {
+	origin := u.Scheme + "://" + u.Host
+	g.mu.Lock()
+	e, ok := g.robots[origin]
+	if !ok || (isClosed(e.ready) && time.Now().After(e.expires)) {
+		e = &robotsEntry{ready: make(chan struct{})}
+		g.robots[origin] = e
+		g.mu.Unlock()
+		e.rules, e.err = g.fetchRobots(ctx, origin, proxy)
+		e.expires = time.Now().Add(robotsTTL)
+		if e.err != nil {
+			// Retry failed fetches on the next navigation.
+			e.expires = time.Now()
+		}
+		close(e.ready)
+	} else {
+		g.mu.Unlock()
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-e.ready:
+		return e.rules, e.err
+	}
+}
+
+// isClosed reports whether c is closed.
+func isClosed(c chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+// httpClient returns the client fetching robots.txt for a tab using the proxy server.
+func (g *NavigationGuard) httpClient(proxy string) (*http.Client, error) {
+	if g.client != nil {
+		return g.client, nil
+	} else if proxy == "" {
+		return http.DefaultClient, nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if client, ok := g.proxies[proxy]; ok {
+		return client, nil
+	}
+	server := proxy
+	if !strings.Contains(server, "://") {
+		server = "http://" + server
+	}
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(u)}}
+	g.proxies[proxy] = client
+	return client, nil
+}
+
+// fetchRobots downloads and parses the robots.txt of an origin.
+func (g *NavigationGuard) fetchRobots(ctx context.Context, origin, proxy string) (*robotsRules, error) {
+	client, err := g.httpClient(proxy)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, robotsTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 500:
+		return &robotsRules{rules: []robotsRule{{false, "/"}}}, nil
+	case resp.StatusCode >= 400:
+		return new(robotsRules), nil
+	}
+	return parseRobots(io.LimitReader(resp.Body, 500<<10), g.userAgent), nil
+}
+
+// Check returns a *DisallowedError if robots.txt disallows rawURL for the user agent.
+// URLs other than http and https are always allowed.
+func (g *NavigationGuard) Check(ctx context.Context, rawURL string) error {
+	return g.check(ctx, rawURL, "")
+}
+
+// check is Check for a tab using the proxy server.
+func (g *NavigationGuard) check(ctx context.Context, rawURL, proxy string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil
+	}
+	rules, err := g.rules(ctx, u, proxy)
+	if err != nil {
+		return err
+	}
+	if !rules.allowed(u.EscapedPath() + strings.TrimSuffix("?"+u.RawQuery, "?")) {
+		return &DisallowedError{rawURL, g.userAgent}
+	}
+	return nil
+}
+
+// Wait blocks until a request to the host of rawURL respects the rate limit, and reserves the slot.
+func (g *NavigationGuard) Wait(ctx context.Context, rawURL string) error {
+	return g.wait(ctx, rawURL, "")
+}
+
+// wait is Wait for a tab using the proxy server.
+func (g *NavigationGuard) wait(ctx context.Context, rawURL, proxy string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil
+	}
+	interval := g.interval
+	if rules, err := g.rules(ctx, u, proxy); err == nil {
+		interval = max(interval, rules.delay)
+	}
+	if interval <= 0 {
+		return nil
+	}
+	g.mu.Lock()
+	now := time.Now()
+	next := now
+	if t := g.hosts[u.Host]; t.After(now) {
+		next = t
+	}
+	g.hosts[u.Host] = next.Add(interval)
+	g.mu.Unlock()
+	return sleep(ctx, next.Sub(now))
+}
+
+// Attach guards document requests of the tab of ctx using Fetch interception.
+// Disallowed requests fail with net::ERR_BLOCKED_BY_CLIENT and allowed ones wait for the rate limit.
+// The guard runs before every handler added to the tab with HandleFetch, EnableFetch, ServeFS or
+// ReplayHAR, whether added before or after it, and allowed requests are then passed on to them.
+// Page.Goto reports a navigation blocked this way with a *DisallowedError.
+func (g *NavigationGuard) Attach(ctx context.Context) error {
+	return g.attach(ctx, "")
+}
+
+// attach is Attach for a tab using the proxy server.
+func (g *NavigationGuard) attach(ctx context.Context, proxy string) error {
+	if err := filterFetch(ctx, func(ctx context.Context, ev *fetch.EventRequestPaused) (bool, error) {
+		if err := g.check(ctx, ev.Request.URL, proxy); err != nil {
+			if e, ok := errors.AsType[*DisallowedError](err); ok {
+				guardBlocked.Store(chromedp.FromContext(ctx).Target, guardBlock{ev.FrameID, e})
+				return false, fetch.FailRequest(ev.RequestID, network.ErrorReasonBlockedByClient).Do(ctx)
+			}
+			return false, err
+		}
+		return true, g.wait(ctx, ev.Request.URL, proxy)
+	}, &fetch.RequestPattern{URLPattern: "*", ResourceType: network.ResourceTypeDocument}); err != nil {
+		return err
+	}
+	t := chromedp.FromContext(ctx).Target
+	context.AfterFunc(ctx, func() { guardBlocked.Delete(t) })
+	return nil
+}
+
+// guardBlock is a document request blocked by a NavigationGuard.
+type guardBlock struct {
+	frameID cdp.FrameID
+	err     *DisallowedError
+}
+
+// guardBlocked holds the last document request blocked by a NavigationGuard in each tab.
+var guardBlocked sync.Map // *chromedp.Target to guardBlock
+
+// resetBlocked forgets the requests blocked in the tab of ctx before a navigation.
+func resetBlocked(ctx context.Context) {
+	guardBlocked.Delete(chromedp.FromContext(ctx).Target)
+}
+
+// blocked returns the error of a request blocked in the frame since resetBlocked, or nil.
+func blocked(ctx context.Context, frameID cdp.FrameID) error {
+	if v, ok := guardBlocked.LoadAndDelete(chromedp.FromContext(ctx).Target); ok && v.(guardBlock).frameID == frameID {
+		return v.(guardBlock).err
+	}
+	return nil
+}
+
+// Guard attaches a NavigationGuard to every tab of this Chrome instance,
+// including tabs opened by NewContext, NewPage and sessions. If the guard has no HTTP client set
+// and a proxy is configured with Proxy, robots.txt is fetched through that proxy.
+// Page.Goto and the Crawler report pages blocked by the guard with a *DisallowedError.
+func (c *Chrome) Guard(g *NavigationGuard) *Chrome {
+	c.guard = g
+	c.actions = append(c.actions, chromedp.ActionFunc(func(ctx context.Context) error {
+		return g.attach(ctx, c.proxy)
+	}))
+	return c
+}
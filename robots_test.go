@@ -0,0 +1,219 @@
+package chrome
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/chromedp"
+)
+
+const testRobots = `# comment
+User-agent: *
+Disallow: /private
+Allow: /private/public
+
+User-agent: testbot
+User-agent: otherbot
+Disallow: /*.pdf$
+Disallow: /secret
+Crawl-delay: 0.2
+`
+
+func TestRobotsMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, path string
+		expect        bool
+	}{
+		{"/private", "/private/a", true},
+		{"/private", "/public", false},
+		{"/*.pdf$", "/a/b.pdf", true},
+		{"/*.pdf$", "/a/b.pdf?x", false},
+		{"/a*c", "/abc/d", true},
+		{"/a$", "/a", true},
+		{"/a$", "/ab", false},
+	} {
+		if res := robotsMatch(tc.pattern, tc.path); res != tc.expect {
+			t.Errorf("%s %s: expected %v; got %v", tc.pattern, tc.path, tc.expect, res)
+		}
+	}
+}
+
+func TestParseRobots(t *testing.T) {
+	star := parseRobots(strings.NewReader(testRobots), "anybot")
+	for path, expect := range map[string]bool{
+		"/":                 true,
+		"/private/a":        false,
+		"/private/public/a": true,
+		"/robots.txt":       true,
+	} {
+		if res := star.allowed(path); res != expect {
+			t.Errorf("*: %s: expected %v; got %v", path, expect, res)
+		}
+	}
+
+	bot := parseRobots(strings.NewReader(testRobots), "TestBot")
+	for path, expect := range map[string]bool{
+		"/private/a": true,
+		"/a.pdf":     false,
+		"/secret/a":  false,
+	} {
+		if res := bot.allowed(path); res != expect {
+			t.Errorf("testbot: %s: expected %v; got %v", path, expect, res)
+		}
+	}
+	if expect := 200 * time.Millisecond; bot.delay != expect {
+		t.Errorf("expected crawl delay %s; got %s", expect, bot.delay)
+	}
+}
+
+func TestNavigationGuard(t *testing.T) {
+	var robots atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			robots.Add(1)
+			fmt.Fprint(w, testRobots)
+			return
+		}
+		fmt.Fprint(w, "<!DOCTYPE html><html><body>ok</body></html>")
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	g := NewNavigationGuard("testbot", 0)
+	if err := g.Check(ctx, ts.URL+"/page"); err != nil {
+		t.Fatal(err)
+	}
+	var e *DisallowedError
+	if err := g.Check(ctx, ts.URL+"/secret"); !errors.As(err, &e) {
+		t.Fatalf("expected *DisallowedError; got %v", err)
+	}
+	if n := robots.Load(); n != 1 {
+		t.Errorf("expected robots.txt to be fetched once; got %d", n)
+	}
+
+	start := time.Now()
+	for range 3 {
+		if err := g.Wait(ctx, ts.URL+"/page"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 400*time.Millisecond {
+		t.Errorf("expected crawl delay to be enforced; took %s", d)
+	}
+}
+
+func TestGuardProxy(t *testing.T) {
+	g := NewNavigationGuard("testbot", 0)
+	New("").Proxy("127.0.0.1:8080").Guard(g)
+	if g.client != nil {
+		t.Fatal("expected shared guard to be unchanged")
+	}
+	client, err := g.httpClient("127.0.0.1:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := client.Transport.(*http.Transport).Proxy(httptest.NewRequest("GET", "http://example.com/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "http://127.0.0.1:8080"; proxy.String() != expect {
+		t.Errorf("expected proxy %s; got %s", expect, proxy)
+	}
+	if client, err := g.httpClient(""); err != nil || client != http.DefaultClient {
+		t.Errorf("expected default client without proxy; got %v, %v", client, err)
+	}
+}
+
+func TestGuardTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	defer func(d time.Duration) { robotsTimeout = d }(robotsTimeout)
+	robotsTimeout = 100 * time.Millisecond
+
+	done := make(chan error, 1)
+	go func() { done <- NewNavigationGuard("testbot", 0).Check(context.Background(), ts.URL+"/page") }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded; got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected robots.txt fetch to time out")
+	}
+}
+
+func TestGuard(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			fmt.Fprint(w, testRobots)
+			return
+		}
+		fmt.Fprint(w, "<!DOCTYPE html><html><body>ok</body></html>")
+	}))
+	defer ts.Close()
+
+	c := testHeadless().Guard(NewNavigationGuard("testbot", 0))
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL+"/page")); err != nil {
+		t.Fatal(err)
+	}
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL+"/secret")); err == nil || !strings.Contains(err.Error(), "ERR_BLOCKED_BY_CLIENT") {
+		t.Errorf("expected blocked navigation; got %v", err)
+	}
+
+	p, err := c.NewPage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if err := p.Goto(ts.URL+"/secret", WaitUntilLoad); err == nil {
+		t.Error("expected blocked navigation")
+	} else if e, ok := errors.AsType[*DisallowedError](err); !ok || e.URL != ts.URL+"/secret" {
+		t.Errorf("expected *DisallowedError; got %v", err)
+	}
+	if err := p.Goto(ts.URL+"/page", WaitUntilLoad); err != nil {
+		t.Fatal(err)
+	}
+
+	// A catch-all handler added after the guard only sees allowed requests.
+	var handled []string
+	var mu sync.Mutex
+	if err := HandleFetch(ctx, func(ctx context.Context, ev *fetch.EventRequestPaused) error {
+		mu.Lock()
+		handled = append(handled, ev.Request.URL)
+		mu.Unlock()
+		return fetch.ContinueRequest(ev.RequestID).Do(ctx)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL+"/secret")); err == nil || !strings.Contains(err.Error(), "ERR_BLOCKED_BY_CLIENT") {
+		t.Errorf("expected blocked navigation with a catch-all handler; got %v", err)
+	}
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL+"/page")); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if slices.Contains(handled, ts.URL+"/secret") || !slices.Contains(handled, ts.URL+"/page") {
+		t.Errorf("expected only allowed requests to reach the handler; got %v", handled)
+	}
+}
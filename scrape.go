@@ -0,0 +1,484 @@
+package chrome
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chromedp/chromedp"
+)
+
+// scrapeScript evaluates a tree of field specifications against the document in a single pass.
+// Each field yields its value, null if no element matched, or an array for repeated fields.
+const scrapeScript = `(specs => {
+	const value = (el, s) => {
+		if (s.fields) return run(el, s.fields);
+		if (s.attr) return el.getAttribute(s.attr);
+		if (s.prop) return el[s.prop] ?? null;
+		if (s.html) return el.innerHTML;
+		return (el.innerText ?? el.textContent).trim();
+	};
+	const run = (root, fields) => fields.map(s => {
+		const els = s.sel ? Array.from(root.querySelectorAll(s.sel)) : [root];
+		if (s.multi) return els.map(el => value(el, s));
+		return els.length ? value(els[0], s) : null;
+	});
+	return run(document.documentElement, specs);
+})(%s)`
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	durationType      = reflect.TypeFor[time.Duration]()
+	textUnmarshalType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// MissingFieldsError is returned by Scrape when required fields are not found in the page.
+type MissingFieldsError struct {
+	Fields []string // Paths of the missing fields, such as Items[2].Price
+}
+
+// Error implements the error interface.
+func (e *MissingFieldsError) Error() string {
+	return "missing required fields: " + strings.Join(e.Fields, ", ")
+}
+
+// scrapeField is the specification of a tagged struct field.
+type scrapeField struct {
+	Selector string         `json:"sel,omitempty"`
+	Attr     string         `json:"attr,omitempty"`
+	Prop     string         `json:"prop,omitempty"`
+	HTML     bool           `json:"html,omitempty"`
+	Multi    bool           `json:"multi,omitempty"`
+	Fields   []*scrapeField `json:"fields,omitempty"`
+
+	name     string
+	index    int
+	optional bool
+	layout   string
+	decimal  rune
+}
+
+// parseScrapeTag parses a chrome struct tag. Commas that do not start a known option
+// belong to the css selector, so selector lists such as "css=h1, h2" are allowed.
+func parseScrapeTag(tag string) (*scrapeField, error) {
+	f := new(scrapeField)
+	var last string
+	var err error
+	for part := range strings.SplitSeq(tag, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key = strings.TrimSpace(key); key {
+		case "css":
+			f.Selector = strings.TrimSpace(value)
+		case "attr":
+			f.Attr = strings.TrimSpace(value)
+		case "prop":
+			f.Prop = strings.TrimSpace(value)
+		case "layout":
+			f.layout = value
+		case "decimal":
+			if f.decimal, err = parseDecimal(value); err != nil {
+				return nil, err
+			}
+		case "html":
+			f.HTML = true
+		case "optional":
+			f.optional = true
+		default:
+			if last != "css" {
+				return nil, fmt.Errorf("unknown option %q", part)
+			}
+			f.Selector += "," + part
+			continue
+		}
+		last = key
+	}
+	return f, nil
+}
+
+// parseDecimal parses the decimal separator option, "point" or "comma".
+func parseDecimal(s string) (rune, error) {
+	switch strings.TrimSpace(s) {
+	case "point":
+		return '.', nil
+	case "comma":
+		return ',', nil
+	default:
+		return 0, fmt.Errorf("unknown decimal separator %q", s)
+	}
+}
+
+// scrapeScalar reports whether t can be converted from a single value.
+func scrapeScalar(t reflect.Type) bool {
+	if t == timeType || t == durationType || reflect.PointerTo(t).Implements(textUnmarshalType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// scrapeFields builds the specifications of the tagged fields of a struct type.
+func scrapeFields(t reflect.Type, seen map[reflect.Type]bool) ([]*scrapeField, error) {
+	if seen[t] {
+		return nil, fmt.Errorf("recursive type %s", t)
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	var fields []*scrapeField
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("chrome")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		f, err := parseScrapeTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t, sf.Name, err)
+		}
+		f.name, f.index = sf.Name, i
+		typ := sf.Type
+		if typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8 {
+			f.Multi, typ = true, typ.Elem()
+		}
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		switch {
+		case scrapeScalar(typ):
+		case typ.Kind() == reflect.Struct:
+			if f.Attr != "" || f.Prop != "" || f.HTML {
+				return nil, fmt.Errorf("%s.%s: struct fields cannot use attr, prop or html", t, sf.Name)
+			}
+			if f.Fields, err = scrapeFields(typ, seen); err != nil {
+				return nil, err
+			}
+			if f.Fields == nil {
+				f.Fields = []*scrapeField{}
+			}
+		default:
+			return nil, fmt.Errorf("%s.%s: unsupported type %s", t, sf.Name, sf.Type)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// isDigit reports whether r is an ASCII digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// scrapeNumber returns the only number in s, such as "1234.50" in "$1,234.50", in the syntax of strconv.
+// decimal is the decimal separator, '.' if zero. Digits may be grouped in threes by spaces, apostrophes
+// or whichever of comma and point is not the decimal separator. A minus sign before the number, even
+// before a currency symbol, makes it negative. It fails if s has no number or digits after the number,
+// as in "1 of 5" or in "1.234,50" with the wrong decimal separator.
+func scrapeNumber(s string, decimal rune) (string, error) {
+	if decimal == 0 {
+		decimal = '.'
+	}
+	group := ','
+	if decimal == ',' {
+		group = '.'
+	}
+	start := strings.IndexFunc(s, isDigit)
+	if start < 0 {
+		return "", fmt.Errorf("no number in %q", s)
+	}
+	var b strings.Builder
+	prefix, fraction := s[:start], false
+	if r, n := utf8.DecodeLastRuneInString(prefix); r == decimal {
+		prefix, fraction = prefix[:len(prefix)-n], true
+	}
+	prefix = strings.TrimRightFunc(prefix, func(r rune) bool { return unicode.IsSymbol(r) || unicode.IsSpace(r) })
+	if strings.HasSuffix(prefix, "-") || strings.HasSuffix(prefix, "\u2212") {
+		b.WriteByte('-')
+	}
+	if fraction {
+		b.WriteString("0.")
+	}
+	digits := func(s string) int {
+		if n := strings.IndexFunc(s, func(r rune) bool { return !isDigit(r) }); n >= 0 {
+			return n
+		}
+		return len(s)
+	}
+	rest := s[start:]
+loop:
+	for {
+		n := digits(rest)
+		b.WriteString(rest[:n])
+		rest = rest[n:]
+		r, size := utf8.DecodeRuneInString(rest)
+		next := rest[size:]
+		switch n := digits(next); {
+		case n == 0:
+			break loop
+		case r == decimal && !fraction:
+			fraction = true
+			b.WriteByte('.')
+		case !fraction && n == 3 && (r == group || r == '\'' || unicode.IsSpace(r)):
+		default:
+			break loop
+		}
+		rest = next
+	}
+	if strings.ContainsFunc(rest, isDigit) {
+		return "", fmt.Errorf("ambiguous number in %q", s)
+	}
+	return b.String(), nil
+}
+
+// scrapeValue returns the numeric value of a JSON number or boolean.
+func scrapeValue(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// convert stores a scraped value in rv.
+// Numbers and booleans read from properties are converted directly, other values are parsed from text.
+func (f *scrapeField) convert(v any, rv reflect.Value) error {
+	s, text := v.(string)
+	if n, ok := v.(float64); ok {
+		s = strconv.FormatFloat(n, 'f', -1, 64)
+	} else if !text {
+		s = fmt.Sprint(v)
+	}
+	switch rv.Type() {
+	case timeType:
+		s = strings.TrimSpace(s)
+		if f.layout != "" {
+			t, err := time.Parse(f.layout, s)
+			if err != nil {
+				return err
+			}
+			rv.Set(reflect.ValueOf(t))
+			return nil
+		}
+		for _, layout := range publishedLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				rv.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot parse %q as time", s)
+	case durationType:
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	}
+	if u, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Bool:
+		switch v := v.(type) {
+		case bool:
+			rv.SetBool(v)
+			return nil
+		case float64:
+			rv.SetBool(v != 0)
+			return nil
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := scrapeValue(v); ok {
+			if n != math.Trunc(n) || n < -(1<<63) || n >= 1<<63 || rv.OverflowInt(int64(n)) {
+				return fmt.Errorf("value %v overflows %s", n, rv.Type())
+			}
+			rv.SetInt(int64(n))
+			return nil
+		}
+		s, err := scrapeNumber(s, f.decimal)
+		if err != nil {
+			return err
+		}
+		n, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := scrapeValue(v); ok {
+			if n != math.Trunc(n) || n < 0 || n >= 1<<64 || rv.OverflowUint(uint64(n)) {
+				return fmt.Errorf("value %v overflows %s", n, rv.Type())
+			}
+			rv.SetUint(uint64(n))
+			return nil
+		}
+		s, err := scrapeNumber(s, f.decimal)
+		if err != nil {
+			return err
+		}
+		n, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		if n, ok := scrapeValue(v); ok {
+			if rv.OverflowFloat(n) {
+				return fmt.Errorf("value %v overflows %s", n, rv.Type())
+			}
+			rv.SetFloat(n)
+			return nil
+		}
+		s, err := scrapeNumber(s, f.decimal)
+		if err != nil {
+			return err
+		}
+		n, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", rv.Type())
+	}
+	return nil
+}
+
+// scrapeDecoder collects missing fields and conversion errors while decoding.
+type scrapeDecoder struct {
+	missing []string
+	errs    []error
+}
+
+// decode stores the result of field f in rv.
+func (d *scrapeDecoder) decode(f *scrapeField, v any, rv reflect.Value, path string) {
+	if !f.Multi {
+		d.value(f, v, rv, path)
+		return
+	}
+	values, _ := v.([]any)
+	slice := reflect.MakeSlice(rv.Type(), len(values), len(values))
+	for i, v := range values {
+		d.value(f, v, slice.Index(i), fmt.Sprintf("%s[%d]", path, i))
+	}
+	rv.Set(slice)
+}
+
+// value stores a single value of field f in rv.
+func (d *scrapeDecoder) value(f *scrapeField, v any, rv reflect.Value, path string) {
+	if v == nil {
+		if !f.optional && rv.Kind() != reflect.Pointer {
+			d.missing = append(d.missing, path)
+		}
+		return
+	}
+	if rv.Kind() == reflect.Pointer {
+		rv.Set(reflect.New(rv.Type().Elem()))
+		rv = rv.Elem()
+	}
+	if f.Fields != nil {
+		d.fields(f.Fields, v, rv, path+".")
+		return
+	}
+	if err := f.convert(v, rv); err != nil {
+		d.errs = append(d.errs, fmt.Errorf("%s: %w", path, err))
+	}
+}
+
+// fields stores the results of the fields of a struct in rv.
+func (d *scrapeDecoder) fields(fields []*scrapeField, v any, rv reflect.Value, prefix string) {
+	values, _ := v.([]any)
+	for i, f := range fields {
+		var v any
+		if i < len(values) {
+			v = values[i]
+		}
+		d.decode(f, v, rv.Field(f.index), prefix+f.name)
+	}
+}
+
+// Scrape fills the struct pointed to by v from the rendered page. Fields are described by
+// chrome struct tags with comma-separated options:
+//
+//	css=selector  element to read, relative to the enclosing struct; the element itself if omitted
+//	attr=name     read an attribute instead of the text
+//	prop=name     read a DOM property, such as href for an absolute URL or checked
+//	html          read the inner HTML instead of the text
+//	layout=layout time layout for time.Time fields; common formats are tried if omitted
+//	decimal=comma decimal separator of numbers, point by default
+//	optional      do not report the field as missing
+//
+// For example:
+//
+//	type Product struct {
+//		Name    string    `chrome:"css=h1"`
+//		Price   float64   `chrome:"css=.price,attr=data-value"`
+//		Date    time.Time `chrome:"css=time,attr=datetime"`
+//		Tags    []string  `chrome:"css=.tag"`
+//		Reviews []struct {
+//			Author string `chrome:"css=.author"`
+//			Rating int    `chrome:"css=.rating,optional"`
+//		} `chrome:"css=.review"`
+//	}
+//
+// Nested structs are scoped to the first element matching their selector, and slices collect every
+// matching element. Numbers are parsed from the only number in the text, ignoring characters such as
+// currency symbols and thousands separators; text with more numbers is an error. Pointer fields are
+// left nil when not found. All fields are evaluated in a single round trip. If required fields are
+// not found, the returned error includes a *MissingFieldsError listing all of them, while the other
+// fields are still filled.
+func Scrape(ctx context.Context, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Scrape requires a non-nil pointer to a struct, got %T", v)
+	}
+	fields, err := scrapeFields(rv.Elem().Type(), make(map[reflect.Type]bool))
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	var res any
+	if err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(scrapeScript, b), &res)); err != nil {
+		return err
+	}
+	var d scrapeDecoder
+	d.fields(fields, res, rv.Elem(), "")
+	if len(d.missing) > 0 {
+		d.errs = append([]error{&MissingFieldsError{d.missing}}, d.errs...)
+	}
+	return errors.Join(d.errs...)
+}
+
+// Scrape fills the struct pointed to by v from the page of this Chrome instance.
+func (c *Chrome) Scrape(v any) error {
+	return Scrape(c, v)
+}
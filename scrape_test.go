@@ -0,0 +1,172 @@
+package chrome
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+type testProduct struct {
+	Name    string    `chrome:"css=h1, h2"`
+	Price   float64   `chrome:"css=.price,attr=data-value"`
+	Stock   int       `chrome:"css=.stock"`
+	Date    time.Time `chrome:"css=time,attr=datetime"`
+	Link    string    `chrome:"css=a.more,prop=href"`
+	Tags    []string  `chrome:"css=.tag"`
+	Note    *string   `chrome:"css=.note"`
+	Reviews []struct {
+		Author string `chrome:"css=.author"`
+		Rating int    `chrome:"css=.rating,optional"`
+	} `chrome:"css=.review"`
+}
+
+func TestParseScrapeTag(t *testing.T) {
+	f, err := parseScrapeTag("css=h1, h2 > a,attr=href,optional")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Selector != "h1, h2 > a" || f.Attr != "href" || !f.optional {
+		t.Errorf("unexpected field: %+v", f)
+	}
+	if _, err := parseScrapeTag("attr=href,unknown"); err == nil {
+		t.Error("expected error; got nil")
+	}
+	if f, err := parseScrapeTag("css=.price,decimal=comma"); err != nil {
+		t.Fatal(err)
+	} else if f.decimal != ',' {
+		t.Errorf("expected comma decimal separator; got %q", f.decimal)
+	}
+	if _, err := parseScrapeTag("decimal=dot"); err == nil {
+		t.Error("expected decimal separator error; got nil")
+	}
+}
+
+func TestScrapeNumber(t *testing.T) {
+	for _, tc := range []struct {
+		s       string
+		decimal rune
+		expect  string
+	}{
+		{"$1,234.50", 0, "1234.50"},
+		{"-$5", 0, "-5"},
+		{"Stock: 12 ", 0, "12"},
+		{"1 234 567", 0, "1234567"},
+		{".5", 0, "0.5"},
+		{"1.234,50 €", ',', "1234.50"},
+		{"1.234,50", 0, ""},
+		{"1 of 5", 0, ""},
+		{"1,2", 0, ""},
+		{"none", 0, ""},
+	} {
+		n, err := scrapeNumber(tc.s, tc.decimal)
+		if tc.expect == "" {
+			if err == nil {
+				t.Errorf("%q: expected error; got %q", tc.s, n)
+			}
+		} else if err != nil {
+			t.Errorf("%q: %v", tc.s, err)
+		} else if n != tc.expect {
+			t.Errorf("%q: expected %q; got %q", tc.s, tc.expect, n)
+		}
+	}
+}
+
+func TestScrapeDecode(t *testing.T) {
+	fields, err := scrapeFields(reflect.TypeFor[testProduct](), make(map[reflect.Type]bool))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v testProduct
+	var d scrapeDecoder
+	d.fields(fields, []any{
+		"Widget", "$1,234.50", nil, "2024-05-01", "https://example.com/more",
+		[]any{"a", "b"}, nil, []any{[]any{"Ann", "5"}, []any{nil, nil}},
+	}, reflect.ValueOf(&v).Elem(), "")
+	if v.Name != "Widget" || v.Price != 1234.5 || !v.Date.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) ||
+		!slices.Equal(v.Tags, []string{"a", "b"}) || v.Note != nil || len(v.Reviews) != 2 || v.Reviews[0].Rating != 5 {
+		t.Errorf("unexpected result: %+v", v)
+	}
+	if expect := []string{"Stock", "Reviews[1].Author"}; !slices.Equal(d.missing, expect) {
+		t.Errorf("expected missing %v; got %v", expect, d.missing)
+	}
+
+	// Property values keep their JSON types.
+	var props struct {
+		Time    int64   `chrome:"css=input,prop=valueAsNumber"`
+		Text    string  `chrome:"css=input,prop=valueAsNumber"`
+		Ratio   float32 `chrome:"css=meter,prop=value"`
+		Checked int     `chrome:"css=input,prop=checked"`
+		Small   uint8   `chrome:"css=input,prop=size"`
+	}
+	if fields, err = scrapeFields(reflect.TypeOf(props), make(map[reflect.Type]bool)); err != nil {
+		t.Fatal(err)
+	}
+	d = scrapeDecoder{}
+	d.fields(fields, []any{1.7e12, 1.7e12, 0.5, true, 300.0}, reflect.ValueOf(&props).Elem(), "")
+	if props.Time != 1.7e12 || props.Text != "1700000000000" || props.Ratio != 0.5 || props.Checked != 1 {
+		t.Errorf("unexpected result: %+v", props)
+	}
+	if len(d.errs) != 1 {
+		t.Errorf("expected overflow error; got %v", d.errs)
+	}
+
+	if _, err := scrapeFields(reflect.TypeFor[struct {
+		C chan int `chrome:"css=a"`
+	}](), make(map[reflect.Type]bool)); err == nil {
+		t.Error("expected unsupported type error; got nil")
+	}
+}
+
+func TestScrape(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<!DOCTYPE html><html><body>
+<h2>Widget</h2>
+<span class="price" data-value="19.99">$19.99</span>
+<time datetime="2024-05-01T08:00:00Z">May 1</time>
+<a class="more" href="/more">more</a>
+<span class="tag">a</span><span class="tag">b</span>
+<div class="review"><span class="author">Ann</span><span class="rating">5</span></div>
+<div class="review"><span class="author">Bob</span></div>
+<input type="datetime-local" value="2023-11-14T22:13:20">
+</body></html>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	var v testProduct
+	err := Scrape(ctx, &v)
+	var missing *MissingFieldsError
+	if !errors.As(err, &missing) || !slices.Equal(missing.Fields, []string{"Stock"}) {
+		t.Errorf("expected missing Stock; got %v", err)
+	}
+	if v.Name != "Widget" || v.Price != 19.99 || v.Link != ts.URL+"/more" || !slices.Equal(v.Tags, []string{"a", "b"}) ||
+		len(v.Reviews) != 2 || v.Reviews[1].Author != "Bob" || v.Reviews[1].Rating != 0 {
+		t.Errorf("unexpected result: %+v", v)
+	}
+
+	var input struct {
+		Millis int64 `chrome:"css=input,prop=valueAsNumber"`
+	}
+	if err := Scrape(ctx, &input); err != nil {
+		t.Fatal(err)
+	}
+	if input.Millis != 1.7e12 {
+		t.Errorf("expected 1700000000000; got %d", input.Millis)
+	}
+}
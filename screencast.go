@@ -0,0 +1,287 @@
+package chrome
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// ScreencastFormat specifies how screencast frames are written.
+type ScreencastFormat int
+
+const (
+	// ScreencastMJPEG writes JPEG frames as a Motion JPEG stream to Output.
+	ScreencastMJPEG ScreencastFormat = iota
+	// ScreencastPNGSequence writes numbered PNG files to Dir.
+	ScreencastPNGSequence
+	// ScreencastAPNG writes an animated PNG to Output when the screencast stops.
+	ScreencastAPNG
+)
+
+// ScreencastOptions configures a screencast recording.
+type ScreencastOptions struct {
+	Format    ScreencastFormat // Output format
+	Output    io.Writer        // Destination for ScreencastMJPEG and ScreencastAPNG
+	Dir       string           // Destination directory for ScreencastPNGSequence
+	FrameRate float64          // Maximum frames per second, zero for no limit
+	MaxWidth  int64            // Maximum frame width, zero for no limit
+	MaxHeight int64            // Maximum frame height, zero for no limit
+	Quality   int64            // JPEG quality [0..100] for ScreencastMJPEG
+}
+
+// screencastFrame is a decoded frame with its capture time.
+type screencastFrame struct {
+	data []byte
+	time time.Time
+}
+
+// Screencast records page screencast frames.
+type Screencast struct {
+	opts ScreencastOptions
+
+	frames []screencastFrame // buffered frames for ScreencastAPNG
+	count  int
+	last   time.Time
+	err    error
+
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// StartScreencast starts recording screencast frames of the page.
+// Each frame is acknowledged after it has been written. The recording stops when ctx is done or Stop is called.
+func StartScreencast(ctx context.Context, opts *ScreencastOptions) (*Screencast, error) {
+	if opts == nil {
+		return nil, errors.New("nil screencast options")
+	}
+	switch opts.Format {
+	case ScreencastMJPEG, ScreencastAPNG:
+		if opts.Output == nil {
+			return nil, errors.New("screencast output is required")
+		}
+	case ScreencastPNGSequence:
+		if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported screencast format: %d", opts.Format)
+	}
+
+	s := &Screencast{opts: *opts, done: make(chan struct{})}
+	var lctx context.Context
+	lctx, s.stop = context.WithCancel(ctx)
+	c := make(chan *page.EventScreencastFrame, 1)
+	chromedp.ListenTarget(lctx, func(v any) {
+		if ev, ok := v.(*page.EventScreencastFrame); ok {
+			select {
+			case c <- ev:
+			case <-lctx.Done():
+			}
+		}
+	})
+
+	params := page.StartScreencast()
+	if opts.Format == ScreencastMJPEG {
+		params = params.WithFormat(page.ScreencastFormatJpeg)
+		if opts.Quality > 0 {
+			params = params.WithQuality(opts.Quality)
+		}
+	} else {
+		params = params.WithFormat(page.ScreencastFormatPng)
+	}
+	if opts.MaxWidth > 0 {
+		params = params.WithMaxWidth(opts.MaxWidth)
+	}
+	if opts.MaxHeight > 0 {
+		params = params.WithMaxHeight(opts.MaxHeight)
+	}
+	if err := chromedp.Run(lctx, params); err != nil {
+		s.stop()
+		return nil, err
+	}
+
+	executor := cdp.WithExecutor(lctx, chromedp.FromContext(lctx).Target)
+	go func() {
+		defer close(s.done)
+		for {
+			select {
+			case <-lctx.Done():
+				ctx, cancel := context.WithTimeout(context.WithoutCancel(executor), time.Second)
+				page.StopScreencast().Do(ctx)
+				cancel()
+				if s.opts.Format == ScreencastAPNG && s.err == nil {
+					s.err = writeAPNG(s.opts.Output, s.frames)
+				}
+				s.frames = nil
+				return
+			case ev := <-c:
+				if s.err == nil {
+					s.err = s.write(ev)
+				}
+				page.ScreencastFrameAck(ev.SessionID).Do(executor)
+			}
+		}
+	}()
+	return s, nil
+}
+
+// write writes a frame unless it exceeds the frame rate limit.
+func (s *Screencast) write(ev *page.EventScreencastFrame) error {
+	t := time.Now()
+	if ev.Metadata != nil && ev.Metadata.Timestamp != nil {
+		t = ev.Metadata.Timestamp.Time()
+	}
+	if s.opts.FrameRate > 0 && s.count > 0 && t.Sub(s.last) < time.Duration(float64(time.Second)/s.opts.FrameRate) {
+		return nil
+	}
+	b, err := base64.StdEncoding.DecodeString(ev.Data)
+	if err != nil {
+		return err
+	}
+	s.count++
+	s.last = t
+	switch s.opts.Format {
+	case ScreencastMJPEG:
+		_, err = s.opts.Output.Write(b)
+	case ScreencastPNGSequence:
+		err = os.WriteFile(filepath.Join(s.opts.Dir, fmt.Sprintf("frame-%05d.png", s.count)), b, 0644)
+	case ScreencastAPNG:
+		s.frames = append(s.frames, screencastFrame{b, t})
+	}
+	return err
+}
+
+// Stop stops the recording, writes any buffered output and returns the first error encountered.
+func (s *Screencast) Stop() error {
+	s.stop()
+	<-s.done
+	return s.err
+}
+
+// Frames returns the number of frames recorded. It blocks until the recording has stopped.
+func (s *Screencast) Frames() int {
+	<-s.done
+	return s.count
+}
+
+// writePNGChunk writes a PNG chunk with its length and CRC.
+func writePNGChunk(w io.Writer, typ string, data []byte) error {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint32(len(data)))
+	buf.WriteString(typ)
+	buf.Write(data)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(buf.Bytes()[4:]))
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// pngChunks parses the chunks of an encoded PNG.
+func pngChunks(b []byte) (chunks [][2][]byte, err error) {
+	if len(b) < 8 {
+		return nil, errors.New("invalid png")
+	}
+	for b = b[8:]; len(b) >= 12; {
+		n := binary.BigEndian.Uint32(b)
+		if uint64(len(b)) < 12+uint64(n) {
+			return nil, errors.New("invalid png chunk")
+		}
+		chunks = append(chunks, [2][]byte{b[4:8], b[8 : 8+n]})
+		b = b[12+n:]
+	}
+	return
+}
+
+// writeAPNG writes frames as an infinitely looping animated PNG.
+// All frames are drawn over an opaque black canvas the size of the first frame,
+// so that every frame is encoded with the color type of the IHDR.
+func writeAPNG(w io.Writer, frames []screencastFrame) error {
+	if len(frames) == 0 {
+		return errors.New("no screencast frames")
+	}
+	if _, err := w.Write([]byte("\x89PNG\r\n\x1a\n")); err != nil {
+		return err
+	}
+	var bounds image.Rectangle
+	var seq uint32
+	for i, f := range frames {
+		img, err := png.Decode(bytes.NewReader(f.data))
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			bounds = image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy())
+		}
+		canvas := image.NewNRGBA(bounds)
+		draw.Draw(canvas, bounds, image.Black, image.Point{}, draw.Src)
+		draw.Draw(canvas, bounds, img, img.Bounds().Min, draw.Over)
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, canvas); err != nil {
+			return err
+		}
+		chunks, err := pngChunks(buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			for _, c := range chunks {
+				if string(c[0]) == "IHDR" {
+					if err := writePNGChunk(w, "IHDR", c[1]); err != nil {
+						return err
+					}
+				}
+			}
+			actl := make([]byte, 8)
+			binary.BigEndian.PutUint32(actl, uint32(len(frames)))
+			if err := writePNGChunk(w, "acTL", actl); err != nil {
+				return err
+			}
+		}
+
+		delay := 100 * time.Millisecond
+		if i+1 < len(frames) {
+			delay = max(frames[i+1].time.Sub(f.time), time.Millisecond)
+		}
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], seq)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(bounds.Dx()))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(bounds.Dy()))
+		binary.BigEndian.PutUint16(fctl[20:], uint16(min(delay.Milliseconds(), 65535)))
+		binary.BigEndian.PutUint16(fctl[22:], 1000)
+		seq++
+		if err := writePNGChunk(w, "fcTL", fctl); err != nil {
+			return err
+		}
+		for _, c := range chunks {
+			if string(c[0]) != "IDAT" {
+				continue
+			}
+			if i == 0 {
+				err = writePNGChunk(w, "IDAT", c[1])
+			} else {
+				fdat := binary.BigEndian.AppendUint32(nil, seq)
+				seq++
+				err = writePNGChunk(w, "fdAT", append(fdat, c[1]...))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return writePNGChunk(w, "IEND", nil)
+}
@@ -0,0 +1,131 @@
+package chrome
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/page"
+)
+
+func TestWriteAPNG(t *testing.T) {
+	var frames []screencastFrame
+	now := time.Now()
+	// The second frame is smaller than the first and is padded on the canvas.
+	for i, c := range []color.Color{color.White, color.Black, color.NRGBA{255, 0, 0, 255}} {
+		w, h := 4, 3
+		if i == 1 {
+			w, h = 2, 2
+		}
+		img := image.NewNRGBA(image.Rect(0, 0, w, h))
+		for x := range w {
+			for y := range h {
+				img.Set(x, y, c)
+			}
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, screencastFrame{buf.Bytes(), now.Add(time.Duration(i) * 40 * time.Millisecond)})
+	}
+
+	var buf bytes.Buffer
+	if err := writeAPNG(&buf, frames); err != nil {
+		t.Fatal(err)
+	}
+	chunks, err := pngChunks(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := make(map[string]int)
+	for _, c := range chunks {
+		count[string(c[0])]++
+		if string(c[0]) == "IHDR" && c[1][9] != 2 {
+			t.Errorf("expected truecolor IHDR; got color type %d", c[1][9])
+		}
+		if string(c[0]) == "acTL" && c[1][3] != 3 {
+			t.Errorf("expected 3 frames in acTL; got %d", c[1][3])
+		}
+	}
+	if count["fcTL"] != 3 || count["fdAT"] < 2 || count["IEND"] != 1 {
+		t.Errorf("unexpected chunks: %v", count)
+	}
+	// Every frame must decode as 8-bit truecolor rows, like the IHDR.
+	var data [][]byte
+	for _, c := range chunks {
+		switch string(c[0]) {
+		case "IDAT":
+			data = append(data, c[1])
+		case "fdAT":
+			data = append(data, c[1][4:])
+		}
+	}
+	for i, d := range data {
+		r, err := zlib.NewReader(bytes.NewReader(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect := 3 * (1 + 4*3); len(b) != expect {
+			t.Errorf("frame %d: expected %d bytes of image data; got %d", i, expect, len(b))
+		}
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b, _ := img.At(0, 0).RGBA(); r != 0xffff || g != 0xffff || b != 0xffff {
+		t.Error("expected first frame as default image")
+	}
+
+	if err := writeAPNG(&buf, nil); err == nil {
+		t.Error("expected error for no frames")
+	}
+}
+
+func TestScreencastMJPEG(t *testing.T) {
+	var frame bytes.Buffer
+	if err := jpeg.Encode(&frame, image.NewGray(image.Rect(0, 0, 4, 3)), nil); err != nil {
+		t.Fatal(err)
+	}
+	data := base64.StdEncoding.EncodeToString(frame.Bytes())
+
+	var buf bytes.Buffer
+	s := &Screencast{opts: ScreencastOptions{Format: ScreencastMJPEG, Output: &buf, FrameRate: 10}}
+	now := time.Now()
+	for _, d := range []time.Duration{0, 50 * time.Millisecond, 100 * time.Millisecond} {
+		ts := cdp.TimeSinceEpoch(now.Add(d))
+		if err := s.write(&page.EventScreencastFrame{
+			Data:     data,
+			Metadata: &page.ScreencastFrameMetadata{Timestamp: &ts},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.count != 2 {
+		t.Errorf("expected 2 frames at the frame rate limit; got %d", s.count)
+	}
+	if expect := bytes.Repeat(frame.Bytes(), 2); !bytes.Equal(buf.Bytes(), expect) {
+		t.Errorf("expected concatenated JPEG frames; got %d bytes", buf.Len())
+	}
+	img, err := jpeg.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 4 || b.Dy() != 3 {
+		t.Errorf("unexpected frame size: %v", b)
+	}
+}
@@ -0,0 +1,159 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// ErrElementNotFound is returned when no element matches the selector.
+var ErrElementNotFound = errors.New("element not found")
+
+// ScreenshotOptions configures a screenshot capture.
+// At most one of FullPage, Selector and Clip should be set; without any of them the visible viewport is captured.
+type ScreenshotOptions struct {
+	FullPage    bool                         // Capture the full scrollable page beyond the viewport
+	Selector    string                       // Capture the first element matching the CSS selector
+	Clip        *page.Viewport               // Capture an explicit region in CSS pixels relative to the document
+	Format      page.CaptureScreenshotFormat // Image format, defaults to PNG
+	Quality     int64                        // Compression quality [0..100] for JPEG and WebP
+	Transparent bool                         // Render without the default white background (PNG and WebP only)
+	Scale       float64                      // Device scale factor override, defaults to 1
+}
+
+// elementClipScript returns the document-relative bounding box of the element matching a selector after scrolling it into view.
+const elementClipScript = `(() => {
+	const e = document.querySelector(%s);
+	if (!e) return null;
+	e.scrollIntoView({block: "center", inline: "center"});
+	const r = e.getBoundingClientRect();
+	return {x: r.left + window.scrollX, y: r.top + window.scrollY, width: r.width, height: r.height};
+})()`
+
+// screenshotClip computes the clip region for the options and whether capturing beyond the viewport is required.
+func screenshotClip(ctx context.Context, opts *ScreenshotOptions) (*page.Viewport, bool, error) {
+	switch {
+	case opts.Clip != nil:
+		clip := *opts.Clip
+		return &clip, true, nil
+	case opts.Selector != "":
+		selector, _ := json.Marshal(opts.Selector)
+		var clip *page.Viewport
+		if err := chromedp.Evaluate(fmt.Sprintf(elementClipScript, selector), &clip).Do(ctx); err != nil {
+			return nil, false, err
+		} else if clip == nil {
+			return nil, false, fmt.Errorf("%w: %s", ErrElementNotFound, opts.Selector)
+		}
+		return clip, true, nil
+	case opts.FullPage:
+		_, _, _, _, _, size, err := page.GetLayoutMetrics().Do(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		return &page.Viewport{Width: size.Width, Height: size.Height}, true, nil
+	case opts.Scale != 0:
+		_, _, _, _, viewport, _, err := page.GetLayoutMetrics().Do(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		return &page.Viewport{
+			X:      viewport.PageX,
+			Y:      viewport.PageY,
+			Width:  viewport.ClientWidth,
+			Height: viewport.ClientHeight,
+		}, false, nil
+	}
+	return nil, false, nil
+}
+
+// screenshot returns an action capturing a screenshot into res.
+func screenshot(opts *ScreenshotOptions, res *[]byte) chromedp.Action {
+	if opts == nil {
+		opts = new(ScreenshotOptions)
+	}
+	return chromedp.ActionFunc(func(ctx context.Context) (err error) {
+		clip, beyond, err := screenshotClip(ctx, opts)
+		if err != nil {
+			return
+		}
+		params := page.CaptureScreenshot().WithCaptureBeyondViewport(beyond)
+		if clip != nil {
+			clip.Scale = opts.Scale
+			if clip.Scale == 0 {
+				clip.Scale = 1
+			}
+			params = params.WithClip(clip)
+		}
+		switch opts.Format {
+		case "", page.CaptureScreenshotFormatPng:
+		case page.CaptureScreenshotFormatJpeg, page.CaptureScreenshotFormatWebp:
+			params = params.WithFormat(opts.Format)
+			if opts.Quality > 0 {
+				params = params.WithQuality(opts.Quality)
+			}
+		default:
+			return fmt.Errorf("unsupported screenshot format: %s", opts.Format)
+		}
+		if opts.Transparent {
+			if err = emulation.SetDefaultBackgroundColorOverride().WithColor(&cdp.RGBA{}).Do(ctx); err != nil {
+				return
+			}
+			defer func() {
+				if e := emulation.SetDefaultBackgroundColorOverride().Do(ctx); err == nil {
+					err = e
+				}
+			}()
+		}
+		*res, err = params.Do(ctx)
+		return
+	})
+}
+
+// Screenshot captures a screenshot of the page with the given options.
+// A nil opts captures the visible viewport as PNG.
+func Screenshot(ctx context.Context, opts *ScreenshotOptions) (b []byte, err error) {
+	err = chromedp.Run(ctx, screenshot(opts, &b))
+	return
+}
+
+// WriteScreenshot captures a screenshot of the page and writes it to w.
+func WriteScreenshot(ctx context.Context, opts *ScreenshotOptions, w io.Writer) error {
+	b, err := Screenshot(ctx, opts)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// SaveScreenshot captures a screenshot of the page and saves it to the named file.
+func SaveScreenshot(ctx context.Context, opts *ScreenshotOptions, name string) error {
+	b, err := Screenshot(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, b, 0644)
+}
+
+// Screenshot captures a screenshot from this Chrome instance.
+func (c *Chrome) Screenshot(opts *ScreenshotOptions) ([]byte, error) {
+	return Screenshot(c, opts)
+}
+
+// WriteScreenshot captures a screenshot from this Chrome instance and writes it to w.
+func (c *Chrome) WriteScreenshot(opts *ScreenshotOptions, w io.Writer) error {
+	return WriteScreenshot(c, opts, w)
+}
+
+// SaveScreenshot captures a screenshot from this Chrome instance and saves it to the named file.
+func (c *Chrome) SaveScreenshot(opts *ScreenshotOptions, name string) error {
+	return SaveScreenshot(c, opts, name)
+}
@@ -0,0 +1,70 @@
+package chrome
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+func TestScreenshot(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<body style="margin:0"><div id="box" style="width:100px;height:50px;background:red"></div><div style="height:3000px"></div></body>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		opts   *ScreenshotOptions
+		format string
+		width  int
+		height int
+	}{
+		{&ScreenshotOptions{Selector: "#box"}, "png", 100, 50},
+		{&ScreenshotOptions{Selector: "#box", Scale: 2}, "png", 200, 100},
+		{&ScreenshotOptions{Clip: &page.Viewport{Width: 30, Height: 20}, Format: page.CaptureScreenshotFormatJpeg, Quality: 80}, "jpeg", 30, 20},
+		{&ScreenshotOptions{FullPage: true}, "png", 0, 3050},
+	}
+	for _, tc := range testcases {
+		b, err := Screenshot(ctx, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config, format, err := image.DecodeConfig(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if format != tc.format {
+			t.Errorf("expected %s; got %s", tc.format, format)
+		}
+		if tc.width != 0 && config.Width != tc.width {
+			t.Errorf("expected width %d; got %d", tc.width, config.Width)
+		}
+		if config.Height != tc.height {
+			t.Errorf("expected height %d; got %d", tc.height, config.Height)
+		}
+	}
+
+	if _, err := Screenshot(ctx, &ScreenshotOptions{Selector: "#none"}); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("expected ErrElementNotFound; got %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package chrome
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/domstorage"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// SessionOptions configures a new Session.
+type SessionOptions struct {
+	ProxyServer     string // Proxy server for the session, similar to --proxy-server
+	ProxyBypassList string // Hosts bypassing the proxy, similar to --proxy-bypass-list
+	DownloadPath    string // Directory to save downloads, empty to keep the browser default
+}
+
+// Session represents an isolated browser context inside a Chrome instance.
+// Each session has its own tabs, cookies, storage and download directory.
+// A Session is also a context.Context bound to its primary tab,
+// so it can be passed to package-level helpers such as ListenEvent and Download.
+type Session struct {
+	context.Context
+
+	c      *Chrome
+	root   context.Context // Browser context of the Chrome instance
+	id     cdp.BrowserContextID
+	opts   SessionOptions
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	cancels []context.CancelFunc
+	closed  bool
+}
+
+// NewSession creates a new isolated browser context and opens its primary tab.
+// A nil opts uses the default options.
+func (c *Chrome) NewSession(opts *SessionOptions) (*Session, error) {
+	if opts == nil {
+		opts = new(SessionOptions)
+	}
+	ctx, _, _, err := c.context(context.Background(), true)
+	if err != nil {
+		return nil, err
+	}
+	params := target.CreateBrowserContext().WithDisposeOnDetach(true)
+	if opts.ProxyServer != "" {
+		params = params.WithProxyServer(opts.ProxyServer)
+	}
+	if opts.ProxyBypassList != "" {
+		params = params.WithProxyBypassList(opts.ProxyBypassList)
+	}
+	id, err := params.Do(cdp.WithExecutor(ctx, chromedp.FromContext(ctx).Browser))
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{c: c, root: ctx, id: id, opts: *opts}
+	if s.Context, s.cancel, err = s.newTab(ctx); err != nil {
+		s.dispose()
+		return nil, err
+	}
+	return s, nil
+}
+
+// ID returns the browser context ID of the session.
+func (s *Session) ID() cdp.BrowserContextID {
+	return s.id
+}
+
+// newTab opens a new tab in the session's browser context.
+func (s *Session) newTab(parent context.Context) (context.Context, context.CancelFunc, error) {
+	ctx, cancel := chromedp.NewContext(parent, append(slices.Clip(s.c.ctxOpts), chromedp.WithExistingBrowserContext(s.id))...)
+	if err := chromedp.Run(ctx, s.c.actions...); err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	s.mu.Lock()
+	path := s.opts.DownloadPath
+	s.mu.Unlock()
+	if path != "" {
+		if err := s.setDownload(ctx, path); err != nil {
+			cancel()
+			return nil, nil, err
+		}
+	}
+	return ctx, cancel, nil
+}
+
+// newContext creates a new tab in the session with optional timeout.
+func (s *Session) newContext(timeout time.Duration) (context.Context, context.CancelFunc, error) {
+	parent, parentCancel := s.root, context.CancelFunc(func() {})
+	if timeout > 0 {
+		parent, parentCancel = context.WithTimeout(parent, timeout)
+	}
+	ctx, ctxCancel, err := s.newTab(parent)
+	if err != nil {
+		parentCancel()
+		return nil, nil, err
+	}
+	cancel := func() { ctxCancel(); parentCancel() }
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		cancel()
+		return nil, nil, context.Canceled
+	}
+	s.cancels = append(s.cancels, cancel)
+	return ctx, cancel, nil
+}
+
+// NewContext creates a new tab in the session without timeout.
+func (s *Session) NewContext() (context.Context, context.CancelFunc, error) {
+	return s.newContext(0)
+}
+
+// WithTimeout creates a new tab in the session with the specified timeout duration.
+func (s *Session) WithTimeout(timeout time.Duration) (context.Context, context.CancelFunc, error) {
+	return s.newContext(timeout)
+}
+
+// Run executes the provided chromedp actions in the session's primary tab.
+func (s *Session) Run(actions ...chromedp.Action) error {
+	return chromedp.Run(s, actions...)
+}
+
+// setDownload configures the session's browser context to save downloads to the specified path.
+func (s *Session) setDownload(ctx context.Context, path string) error {
+	return chromedp.Run(ctx, browser.SetDownloadBehavior(browser.SetDownloadBehaviorBehaviorAllowAndName).
+		WithBrowserContextID(s.id).
+		WithDownloadPath(path).
+		WithEventsEnabled(true))
+}
+
+// SetDownload configures the session to save downloads to the specified path.
+func (s *Session) SetDownload(path string) error {
+	if err := s.setDownload(s, path); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.opts.DownloadPath = path
+	s.mu.Unlock()
+	return nil
+}
+
+// ListenDownload listens for downloads from the session's primary tab.
+func (s *Session) ListenDownload(url any) <-chan *DownloadEvent {
+	return ListenDownload(s, url)
+}
+
+// Download navigates the session's primary tab to a URL and waits for a download.
+func (s *Session) Download(url string, match any) (*DownloadEvent, error) {
+	return Download(s, url, match)
+}
+
+// Ensure Session implements http.CookieJar interface.
+var _ http.CookieJar = &Session{}
+
+// SetCookies sets cookies in the session for the given URL.
+func (s *Session) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	SetCookies(s, u, cookies)
+}
+
+// Cookies retrieves cookies from the session for the given URL.
+func (s *Session) Cookies(u *url.URL) []*http.Cookie {
+	return Cookies(s, u)
+}
+
+// SetStorageItem sets a storage item in the session.
+func (s *Session) SetStorageItem(storageID *domstorage.StorageID, key, value string) error {
+	return SetStorageItem(s, storageID, key, value)
+}
+
+// StorageItems retrieves storage items from the session.
+func (s *Session) StorageItems(storageID *domstorage.StorageID) ([]domstorage.Item, error) {
+	return StorageItems(s, storageID)
+}
+
+// dispose disposes the session's browser context.
+func (s *Session) dispose() error {
+	ctx, cancel := context.WithTimeout(s.root, 5*time.Second)
+	defer cancel()
+	return target.DisposeBrowserContext(s.id).Do(cdp.WithExecutor(ctx, chromedp.FromContext(s.root).Browser))
+}
+
+// Close closes all tabs of the session and disposes its browser context.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	cancels := s.cancels
+	s.cancels = nil
+	s.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	s.cancel()
+	return s.dispose()
+}
@@ -0,0 +1,60 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestSession(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Test")
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	s1, err := c.NewSession(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s1.Close()
+	s2, err := c.NewSession(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s2.Close()
+
+	if s1.ID() == s2.ID() {
+		t.Fatalf("expected different browser contexts; got %s", s1.ID())
+	}
+
+	ctx, cancel := context.WithTimeout(s1, 10*time.Second)
+	defer cancel()
+
+	u, _ := url.Parse(ts.URL)
+	SetCookies(ctx, u, []*http.Cookie{{Name: "test", Value: "value"}})
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	if cookies := s1.Cookies(u); len(cookies) != 1 {
+		t.Errorf("expected 1 cookie in session 1; got %d", len(cookies))
+	}
+	if cookies := s2.Cookies(u); len(cookies) != 0 {
+		t.Errorf("expected no cookies in session 2; got %d", len(cookies))
+	}
+
+	if err := s1.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if s1.Err() == nil {
+		t.Error("expected session context to be canceled after Close")
+	}
+}
@@ -0,0 +1,279 @@
+package chrome
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// extractTableScript expands the cells of a table into a grid, repeating the text of cells spanning
+// several rows or columns, and separates header rows from body rows.
+const extractTableScript = `(selector => {
+	let table = document.querySelector(selector);
+	if (table && table.tagName !== "TABLE") table = table.querySelector("table");
+	if (!table) return null;
+	const clean = cell => {
+		const clone = cell.cloneNode(true);
+		for (const el of clone.querySelectorAll("script, style, template")) el.remove();
+		for (const el of clone.querySelectorAll("br")) el.replaceWith(" ");
+		return clone.textContent.replace(/\s+/g, " ").trim();
+	};
+	const rows = Array.from(table.rows);
+	const grid = rows.map(() => []);
+	const header = [];
+	rows.forEach((row, r) => {
+		let c = 0;
+		for (const cell of row.cells) {
+			while (grid[r][c] !== undefined) c++;
+			const text = clean(cell);
+			const rowSpan = cell.rowSpan > 0 ? cell.rowSpan : rows.length - r;
+			for (let i = 0; i < rowSpan && r + i < rows.length; i++) {
+				for (let j = 0; j < Math.max(cell.colSpan, 1); j++) grid[r + i][c + j] = text;
+			}
+			c += Math.max(cell.colSpan, 1);
+		}
+		const isHeader = row.parentElement.tagName === "THEAD" ||
+			(!table.tHead && r === header.length && row.cells.length && Array.from(row.cells).every(cell => cell.tagName === "TH"));
+		if (isHeader) header.push(r);
+	});
+	const width = Math.max(0, ...grid.map(row => row.length));
+	for (const row of grid) {
+		for (let c = 0; c < width; c++) if (row[c] === undefined) row[c] = "";
+	}
+	return {
+		caption: table.caption ? clean(table.caption) : "",
+		header: header.map(r => grid[r]),
+		rows: grid.filter((_, r) => !header.includes(r)),
+	};
+})(%s)`
+
+// Table is the content of an HTML table with row and column spans expanded.
+type Table struct {
+	Caption string     // Text of the caption
+	Header  []string   // Column names, empty if the table has no header row
+	Rows    [][]string // Body rows, each as long as the widest row
+}
+
+// Records returns the header, if any, followed by the body rows.
+func (t *Table) Records() [][]string {
+	if len(t.Header) == 0 {
+		return t.Rows
+	}
+	return append([][]string{t.Header}, t.Rows...)
+}
+
+// keys returns unique column names. Columns without a name are numbered from 1 and
+// repeated names get a suffix such as "Name_2".
+func (t *Table) keys() []string {
+	width := len(t.Header)
+	for _, row := range t.Rows {
+		width = max(width, len(row))
+	}
+	keys := make([]string, width)
+	seen := make(map[string]int)
+	for i := range keys {
+		key := strconv.Itoa(i + 1)
+		if i < len(t.Header) && t.Header[i] != "" {
+			key = t.Header[i]
+		}
+		if seen[key]++; seen[key] > 1 {
+			key = fmt.Sprintf("%s_%d", key, seen[key])
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+// Maps returns the body rows as maps keyed by column name. Columns without a name are keyed
+// by their position starting from 1, and repeated names get a suffix such as "Name_2".
+func (t *Table) Maps() []map[string]string {
+	keys := t.keys()
+	maps := make([]map[string]string, len(t.Rows))
+	for i, row := range t.Rows {
+		m := make(map[string]string, len(keys))
+		for j, key := range keys {
+			if j < len(row) {
+				m[key] = row[j]
+			} else {
+				m[key] = ""
+			}
+		}
+		maps[i] = m
+	}
+	return maps
+}
+
+// WriteCSV writes the header and body rows to w as CSV.
+func (t *Table) WriteCSV(w io.Writer) error {
+	return csv.NewWriter(w).WriteAll(t.Records())
+}
+
+// parseTableTag parses the options of a table struct tag following the column name.
+// A layout may itself contain commas, as in "layout=Jan 2, 2006".
+func parseTableTag(opts string, hasOpts bool) (*scrapeField, error) {
+	f := new(scrapeField)
+	if !hasOpts {
+		return f, nil
+	}
+	var last string
+	var err error
+	for part := range strings.SplitSeq(opts, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		switch key = strings.TrimSpace(key); {
+		case ok && key == "layout":
+			f.layout = value
+		case ok && key == "decimal":
+			if f.decimal, err = parseDecimal(value); err != nil {
+				return nil, err
+			}
+		case !ok && last == "layout":
+			f.layout += "," + part
+			continue
+		default:
+			return nil, fmt.Errorf("unknown option %q", part)
+		}
+		last = key
+	}
+	return f, nil
+}
+
+// Decode stores the body rows in the slice pointed to by v, whose elements are structs or
+// pointers to structs. Fields are matched to columns by the table struct tag, or by field name
+// ignoring case if untagged. A tag may add a time layout, as in `table:"Date,layout=2006-01-02"`,
+// or a decimal separator, as in `table:"Price,decimal=comma"`, other options are an error, and "-"
+// skips the field. Cells are converted like in Scrape, and empty cells leave the zero value.
+func (t *Table) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("Decode requires a non-nil pointer to a slice, got %T", v)
+	}
+	slice := rv.Elem()
+	elem := slice.Type().Elem()
+	ptr := elem.Kind() == reflect.Pointer
+	if ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("Decode requires a slice of structs, got %T", v)
+	}
+
+	columns := make(map[string]int)
+	for i, key := range t.keys() {
+		if _, ok := columns[strings.ToLower(key)]; !ok {
+			columns[strings.ToLower(key)] = i
+		}
+	}
+	type column struct {
+		field, index int
+		spec         *scrapeField
+	}
+	var cols []column
+	for i := range elem.NumField() {
+		sf := elem.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, tagged := sf.Tag.Lookup("table")
+		if tag == "-" {
+			continue
+		}
+		name, opts, hasOpts := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		spec, err := parseTableTag(opts, hasOpts)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", elem, sf.Name, err)
+		}
+		typ := sf.Type
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if !scrapeScalar(typ) {
+			if tagged {
+				return fmt.Errorf("%s.%s: unsupported type %s", elem, sf.Name, sf.Type)
+			}
+			continue
+		}
+		index, ok := columns[strings.ToLower(name)]
+		if !ok {
+			if tagged {
+				return fmt.Errorf("%s.%s: column %q not found", elem, sf.Name, name)
+			}
+			continue
+		}
+		cols = append(cols, column{i, index, spec})
+	}
+
+	var errs []error
+	res := reflect.MakeSlice(slice.Type(), len(t.Rows), len(t.Rows))
+	for i, row := range t.Rows {
+		item := res.Index(i)
+		if ptr {
+			item.Set(reflect.New(elem))
+			item = item.Elem()
+		}
+		for _, col := range cols {
+			if col.index >= len(row) || row[col.index] == "" {
+				continue
+			}
+			fv := item.Field(col.field)
+			if fv.Kind() == reflect.Pointer {
+				fv.Set(reflect.New(fv.Type().Elem()))
+				fv = fv.Elem()
+			}
+			if err := col.spec.convert(row[col.index], fv); err != nil {
+				errs = append(errs, fmt.Errorf("row %d: %s: %w", i+1, elem.Field(col.field).Name, err))
+			}
+		}
+	}
+	slice.Set(res)
+	return errors.Join(errs...)
+}
+
+// ExtractTable extracts the table matching selector, or the first table inside the matching
+// element. Header rows are the rows of thead, or else a first row made only of th cells; several
+// header rows are combined column by column. Cells spanning several rows or columns are repeated
+// in each of them, and cell text has scripts removed and whitespace collapsed.
+func ExtractTable(ctx context.Context, selector string) (*Table, error) {
+	b, err := json.Marshal(selector)
+	if err != nil {
+		return nil, err
+	}
+	var res *struct {
+		Caption string     `json:"caption"`
+		Header  [][]string `json:"header"`
+		Rows    [][]string `json:"rows"`
+	}
+	if err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(extractTableScript, b), &res)); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, fmt.Errorf("%w: %s", ErrElementNotFound, selector)
+	}
+	t := &Table{Caption: res.Caption, Rows: res.Rows}
+	for _, row := range res.Header {
+		for i, cell := range row {
+			switch {
+			case i >= len(t.Header):
+				t.Header = append(t.Header, cell)
+			case cell != "" && t.Header[i] != cell:
+				t.Header[i] = strings.TrimSpace(t.Header[i] + " " + cell)
+			}
+		}
+	}
+	return t, nil
+}
+
+// ExtractTable extracts a table from the page of this Chrome instance.
+func (c *Chrome) ExtractTable(selector string) (*Table, error) {
+	return ExtractTable(c, selector)
+}
@@ -0,0 +1,114 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestTable(t *testing.T) {
+	table := &Table{
+		Header: []string{"Name", "Price", "", "Name"},
+		Rows: [][]string{
+			{"a", "$1,000", "x", "b"},
+			{"c", "", "y", "d"},
+		},
+	}
+	if expect := []map[string]string{
+		{"Name": "a", "Price": "$1,000", "3": "x", "Name_2": "b"},
+		{"Name": "c", "Price": "", "3": "y", "Name_2": "d"},
+	}; !reflect.DeepEqual(table.Maps(), expect) {
+		t.Errorf("expected %v; got %v", expect, table.Maps())
+	}
+
+	var b strings.Builder
+	if err := table.WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "Name,Price,,Name\na,\"$1,000\",x,b\nc,,y,d\n"; b.String() != expect {
+		t.Errorf("expected %q; got %q", expect, b.String())
+	}
+
+	var rows []struct {
+		Name  string
+		Cost  int     `table:"price"`
+		Other *string `table:"Name_2"`
+		Skip  string  `table:"-"`
+	}
+	if err := table.Decode(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Name != "a" || rows[0].Cost != 1000 || *rows[0].Other != "b" || rows[1].Cost != 0 {
+		t.Errorf("unexpected result: %+v", rows)
+	}
+
+	var missing []struct {
+		Value string `table:"Value"`
+	}
+	if err := table.Decode(&missing); err == nil {
+		t.Error("expected error; got nil")
+	}
+
+	dated := &Table{Header: []string{"Date", "Price"}, Rows: [][]string{{"Nov 14, 2023", "1.234,5"}}}
+	var prices []struct {
+		Date  time.Time `table:"Date,layout=Jan 2, 2006"`
+		Price float64   `table:"Price,decimal=comma"`
+	}
+	if err := dated.Decode(&prices); err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || !prices[0].Date.Equal(time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC)) || prices[0].Price != 1234.5 {
+		t.Errorf("unexpected result: %+v", prices)
+	}
+	var unknown []struct {
+		Price float64 `table:"Price,decimal=comma,strict"`
+	}
+	if err := dated.Decode(&unknown); err == nil || !strings.Contains(err.Error(), `unknown option "strict"`) {
+		t.Errorf("expected unknown option error; got %v", err)
+	}
+}
+
+func TestExtractTable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<!DOCTYPE html><html><body><div id="data"><table>
+<caption>Prices</caption>
+<thead><tr><th rowspan="2">Name</th><th colspan="2">Price</th></tr><tr><th>Old</th><th>New</th></tr></thead>
+<tbody>
+<tr><td rowspan="2">A<script>x</script></td><td>1</td><td>2</td></tr>
+<tr><td colspan="2">  3
+  <br>now </td></tr>
+</tbody></table></div></body></html>`)
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	table, err := ExtractTable(ctx, "#data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.Caption != "Prices" {
+		t.Errorf("expected caption %q; got %q", "Prices", table.Caption)
+	}
+	if expect := []string{"Name", "Price Old", "Price New"}; !slices.Equal(table.Header, expect) {
+		t.Errorf("expected header %q; got %q", expect, table.Header)
+	}
+	if expect := [][]string{{"A", "1", "2"}, {"A", "3 now", "3 now"}}; !reflect.DeepEqual(table.Rows, expect) {
+		t.Errorf("expected rows %q; got %q", expect, table.Rows)
+	}
+}
@@ -0,0 +1,342 @@
+package chrome
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// WARCWriter writes captured network exchanges as ISO 28500 WARC files.
+// Each record is compressed as a separate gzip member, and a new file is started
+// once the current one reaches the configured maximum size.
+type WARCWriter struct {
+	dir     string
+	prefix  string
+	maxSize int64
+
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	file   *os.File
+	size   int64
+	serial int
+	files  []string
+	errs   []error
+}
+
+// NewWARCWriter creates a WARCWriter writing files named prefix-<timestamp>-<serial>.warc.gz into dir.
+// If maxSize is greater than zero, files are rotated once they reach maxSize bytes.
+func NewWARCWriter(dir, prefix string, maxSize int64) *WARCWriter {
+	return &WARCWriter{dir: dir, prefix: prefix, maxSize: maxSize}
+}
+
+// Attach records network events matching the URL pattern from ctx until ctx is done.
+// Response bodies are downloaded and stored in the response records, as are request bodies
+// too large to be included in the events.
+func (w *WARCWriter) Attach(ctx context.Context, url any) {
+	c := ListenEvent(ctx, url, "", true)
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		for e := range c {
+			if err := fetchPostData(ctx, e); err != nil {
+				slog.Debug(err.Error())
+			}
+			if err := w.WriteEvent(e); err != nil {
+				w.mu.Lock()
+				w.errs = append(w.errs, err)
+				w.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// Files returns the names of the files written so far.
+func (w *WARCWriter) Files() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.files)
+}
+
+// Close waits for all attached contexts to be done and closes the current file.
+// It returns the errors encountered while recording attached contexts.
+func (w *WARCWriter) Close() error {
+	w.wg.Wait()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	errs := w.errs
+	w.errs = nil
+	if w.file != nil {
+		errs = append(errs, w.file.Close())
+		w.file = nil
+	}
+	return errors.Join(errs...)
+}
+
+// WriteEvent writes the request, response and metadata records of a network event.
+// Events without a response are ignored.
+func (w *WARCWriter) WriteEvent(e *Event) error {
+	if e.Request == nil || e.Response == nil {
+		return nil
+	}
+	date := time.Now()
+	if e.Request.WallTime != nil {
+		date = time.Time(*e.Request.WallTime)
+	}
+	uri := e.Request.Request.URL
+	resp := e.Response.Response
+
+	responseID := warcRecordID()
+	records := []*warcRecord{
+		{
+			typ:  "response",
+			id:   responseID,
+			date: date,
+			header: [][2]string{
+				{"WARC-Target-URI", uri},
+				{"WARC-IP-Address", resp.RemoteIPAddress},
+				{"Content-Type", "application/http; msgtype=response"},
+			},
+			block: httpResponseBlock(resp, e.Bytes),
+		},
+		{
+			typ:  "request",
+			id:   warcRecordID(),
+			date: date,
+			header: [][2]string{
+				{"WARC-Target-URI", uri},
+				{"WARC-Concurrent-To", responseID},
+				{"Content-Type", "application/http; msgtype=request"},
+			},
+			block: httpRequestBlock(e.Request.Request, resp),
+		},
+		{
+			typ:  "metadata",
+			id:   warcRecordID(),
+			date: date,
+			header: [][2]string{
+				{"WARC-Target-URI", uri},
+				{"WARC-Concurrent-To", responseID},
+				{"Content-Type", "application/warc-fields"},
+			},
+			block: warcFields([][2]string{
+				{"resourceType", string(e.Request.Type)},
+				{"documentURL", e.Request.DocumentURL},
+				{"mimeType", resp.MimeType},
+				{"protocol", resp.Protocol},
+				{"fromDiskCache", strconv.FormatBool(resp.FromDiskCache)},
+				{"fromServiceWorker", strconv.FormatBool(resp.FromServiceWorker)},
+			}),
+		},
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, r := range records {
+		if err := w.write(r); err != nil {
+			return err
+		}
+	}
+	if w.maxSize > 0 && w.size >= w.maxSize {
+		err := w.file.Close()
+		w.file = nil
+		return err
+	}
+	return nil
+}
+
+// write writes a record to the current file, starting a new file if needed.
+func (w *WARCWriter) write(r *warcRecord) error {
+	if w.file == nil {
+		name := filepath.Join(w.dir, fmt.Sprintf("%s-%s-%05d.warc.gz", w.prefix, time.Now().UTC().Format("20060102150405"), w.serial))
+		f, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		w.file, w.size = f, 0
+		w.serial++
+		w.files = append(w.files, name)
+		info := &warcRecord{
+			typ:    "warcinfo",
+			id:     warcRecordID(),
+			date:   time.Now(),
+			header: [][2]string{{"WARC-Filename", filepath.Base(name)}, {"Content-Type", "application/warc-fields"}},
+			block:  warcFields([][2]string{{"software", "github.com/sunshineplan/chrome"}, {"format", "WARC File Format 1.1"}}),
+		}
+		if err := w.write(info); err != nil {
+			return err
+		}
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := r.WriteTo(gz); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	n, err := w.file.Write(buf.Bytes())
+	w.size += int64(n)
+	return err
+}
+
+// warcRecord represents a single WARC record.
+type warcRecord struct {
+	typ    string
+	id     string
+	date   time.Time
+	header [][2]string
+	block  []byte
+}
+
+// WriteTo writes the uncompressed record to w.
+func (r *warcRecord) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	digest := sha1.Sum(r.block)
+	fmt.Fprintf(&buf, "WARC/1.1\r\nWARC-Type: %s\r\nWARC-Record-ID: %s\r\nWARC-Date: %s\r\n",
+		r.typ, r.id, r.date.UTC().Format(time.RFC3339Nano))
+	for _, i := range r.header {
+		if i[1] != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", i[0], i[1])
+		}
+	}
+	fmt.Fprintf(&buf, "WARC-Block-Digest: sha1:%s\r\nContent-Length: %d\r\n\r\n",
+		base32.StdEncoding.EncodeToString(digest[:]), len(r.block))
+	buf.Write(r.block)
+	buf.WriteString("\r\n\r\n")
+	return buf.WriteTo(w)
+}
+
+// warcRecordID returns a new random WARC record ID.
+func warcRecordID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6], b[8] = b[6]&0x0f|0x40, b[8]&0x3f|0x80
+	return fmt.Sprintf("<urn:uuid:%x-%x-%x-%x-%x>", b[:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// warcFields encodes fields in the application/warc-fields format.
+func warcFields(fields [][2]string) []byte {
+	var buf bytes.Buffer
+	for _, i := range fields {
+		if i[1] != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", i[0], i[1])
+		}
+	}
+	return buf.Bytes()
+}
+
+// writeHeaders writes protocol headers in wire format, splitting multi-value headers joined by newlines.
+// Headers in skip are omitted.
+func writeHeaders(w io.Writer, headers network.Headers, skip ...string) {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		if !slices.ContainsFunc(skip, func(s string) bool { return strings.EqualFold(s, k) }) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		for v := range strings.SplitSeq(fmt.Sprint(headers[k]), "\n") {
+			fmt.Fprintf(w, "%s: %s\r\n", k, v)
+		}
+	}
+}
+
+// httpVersion returns the HTTP version used in the message start line for a protocol.
+func httpVersion(protocol string) string {
+	switch strings.ToLower(protocol) {
+	case "http/1.0":
+		return "HTTP/1.0"
+	case "h2":
+		return "HTTP/2.0"
+	case "h3":
+		return "HTTP/3.0"
+	default:
+		return "HTTP/1.1"
+	}
+}
+
+// fetchPostData stores the body of a request whose post data is not included in the event,
+// as Chrome omits large bodies, in its PostDataEntries.
+func fetchPostData(ctx context.Context, e *Event) error {
+	req := e.Request.Request
+	if !req.HasPostData || len(req.PostDataEntries) > 0 {
+		return nil
+	}
+	return chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		data, err := network.GetRequestPostData(e.Request.RequestID).Do(ctx)
+		if err != nil {
+			return err
+		}
+		req.PostDataEntries = []*network.PostDataEntry{{Bytes: base64.StdEncoding.EncodeToString(data)}}
+		return nil
+	}))
+}
+
+// postData returns the decoded request body.
+func postData(req *network.Request) []byte {
+	var b []byte
+	for _, i := range req.PostDataEntries {
+		data, err := base64.StdEncoding.DecodeString(i.Bytes)
+		if err == nil {
+			b = append(b, data...)
+		}
+	}
+	return b
+}
+
+// httpRequestBlock reconstructs the HTTP request message.
+func httpRequestBlock(req *network.Request, resp *network.Response) []byte {
+	var buf bytes.Buffer
+	target := req.URL
+	if i := strings.Index(target, "://"); i >= 0 {
+		if j := strings.Index(target[i+3:], "/"); j >= 0 {
+			target = target[i+3+j:]
+		} else {
+			target = "/"
+		}
+	}
+	fmt.Fprintf(&buf, "%s %s %s\r\n", req.Method, target, httpVersion(resp.Protocol))
+	headers := req.Headers
+	if len(resp.RequestHeaders) > 0 {
+		headers = resp.RequestHeaders
+	}
+	writeHeaders(&buf, headers)
+	buf.WriteString("\r\n")
+	buf.Write(postData(req))
+	return buf.Bytes()
+}
+
+// httpResponseBlock reconstructs the HTTP response message.
+// The body is stored decoded, so content and transfer encodings are dropped and Content-Length is recomputed.
+func httpResponseBlock(resp *network.Response, body []byte) []byte {
+	var buf bytes.Buffer
+	text := resp.StatusText
+	if text == "" {
+		text = http.StatusText(int(resp.Status))
+	}
+	fmt.Fprintf(&buf, "%s %d %s\r\n", httpVersion(resp.Protocol), resp.Status, text)
+	writeHeaders(&buf, resp.Headers, "Content-Encoding", "Transfer-Encoding", "Content-Length")
+	fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n", len(body))
+	buf.Write(body)
+	return buf.Bytes()
+}
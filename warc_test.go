@@ -0,0 +1,176 @@
+package chrome
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+func TestWARCWriter(t *testing.T) {
+	dir := t.TempDir()
+	w := NewWARCWriter(dir, "test", 1)
+	e := &Event{
+		Request: &network.EventRequestWillBeSent{
+			Request: &network.Request{
+				URL:     "https://example.com/path?q=1",
+				Method:  "POST",
+				Headers: network.Headers{"Accept": "*/*"},
+				PostDataEntries: []*network.PostDataEntry{
+					{Bytes: "a2V5PXZhbHVl"},
+				},
+			},
+		},
+		Response: &network.EventResponseReceived{
+			Response: &network.Response{
+				Status:   200,
+				Protocol: "h2",
+				Headers: network.Headers{
+					"Content-Type":     "text/plain",
+					"Content-Encoding": "gzip",
+					"Set-Cookie":       "a=1\nb=2",
+				},
+			},
+		},
+		Bytes: []byte("hello"),
+	}
+	for range 2 {
+		if err := w.WriteEvent(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := w.Files()
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files; got %d", len(files))
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var types []string
+	var response, request string
+	br := bufio.NewReader(f)
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		gz.Multistream(false)
+		b, err := io.ReadAll(gz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		record := string(b)
+		for line := range strings.SplitSeq(record, "\r\n") {
+			if typ, ok := strings.CutPrefix(line, "WARC-Type: "); ok {
+				types = append(types, typ)
+				switch typ {
+				case "response":
+					response = record
+				case "request":
+					request = record
+				}
+				break
+			}
+		}
+		if err := gz.Reset(br); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if expect := "warcinfo,response,request,metadata"; strings.Join(types, ",") != expect {
+		t.Errorf("expected %s; got %s", expect, strings.Join(types, ","))
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(strings.NewReader(response[strings.Index(response, "\r\n\r\n")+4:])), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("expected status 200; got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Content-Encoding") != "" {
+		t.Error("expected Content-Encoding removed")
+	}
+	if cookies := resp.Header.Values("Set-Cookie"); len(cookies) != 2 {
+		t.Errorf("expected 2 Set-Cookie headers; got %d", len(cookies))
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != "hello" {
+		t.Errorf("expected body %q; got %q", "hello", body)
+	}
+	if !strings.Contains(request, "POST /path?q=1 HTTP/2.0\r\n") || !strings.Contains(request, "\r\n\r\nkey=value") {
+		t.Errorf("unexpected request record: %q", request)
+	}
+}
+
+func TestWARCWriterAttach(t *testing.T) {
+	body := strings.Repeat("x", 1<<20)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/upload" {
+			n, _ := io.Copy(io.Discard, r.Body)
+			fmt.Fprint(w, n)
+			return
+		}
+		fmt.Fprintf(w, `<script>fetch("/upload", {method: "POST", body: "x".repeat(%d)})</script>`, len(body))
+	}))
+	defer ts.Close()
+
+	c := testHeadless()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	w := NewWARCWriter(t.TempDir(), "test", 0)
+	actx, acancel := context.WithCancel(ctx)
+	w.Attach(actx, ts.URL+"/upload")
+	if err := chromedp.Run(ctx, chromedp.Navigate(ts.URL)); err != nil {
+		t.Fatal(err)
+	}
+	if err := WaitNetworkIdle(ctx, 500*time.Millisecond, 0); err != nil {
+		t.Fatal(err)
+	}
+	acancel()
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := w.Files()
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file; got %d", len(files))
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Chrome omits bodies this large from the request event.
+	if !strings.Contains(string(b), "POST /upload HTTP/1.1\r\n") || !strings.Contains(string(b), "\r\n\r\n"+body) {
+		t.Error("expected request record with the large body")
+	}
+}